@@ -0,0 +1,105 @@
+package goutils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupInfo describes a file written by a logger that it is no longer
+// writing to: a backup made by Rotate or the file of a previous day (or
+// hour, see WithRotationInterval).
+type BackupInfo struct {
+	Path    string
+	Size    int64
+	Date    time.Time // date (or hour) in the filename, in the filename time zone
+	Rotated time.Time // stamp added by Rotate, zero for a previous day's file
+
+	interval RotationInterval // of the date
+}
+
+// BackupFiles lists the backups of the local log files (mirror copies
+// included), compressed or not, newest first. Files are matched by name
+// next to the active files; writers from WithWriterFactory are skipped.
+func (b *Blogger) BackupFiles() ([]BackupInfo, error) {
+	if b == nil {
+		return nil, nil
+	}
+	b.mu.Lock()
+	active := make(map[string]bool)
+	var dirs []string
+	var patterns []*regexp.Regexp
+	for _, s := range b.files() {
+		if s.file == nil || active[s.path] {
+			continue
+		}
+		active[s.path] = true
+		dirs = append(dirs, filepath.Dir(s.path))
+		patterns = append(patterns, backupPattern(s.stem))
+	}
+	b.mu.Unlock()
+
+	var backups []BackupInfo
+	for i, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			match := patterns[i].FindStringSubmatch(entry.Name())
+			if match == nil || active[path] || entry.IsDir() {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			backup := BackupInfo{Path: path, Size: info.Size()}
+			backup.Date, backup.interval = parseFileDate(match[1], b.cfg.fileLocation())
+			if match[2] != "" {
+				backup.Rotated, _ = time.Parse(backupTimeFormat, match[2])
+			}
+			backups = append(backups, backup)
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		ti, tj := backups[i].newest(), backups[j].newest()
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return backups[i].Path > backups[j].Path
+	})
+	return backups, nil
+}
+
+// a previous day's file was last written on that day
+func (bi BackupInfo) newest() time.Time {
+	if !bi.Rotated.IsZero() {
+		return bi.Rotated
+	}
+	return bi.Date.Add(bi.interval.duration())
+}
+
+// daily or hourly date of a filename
+func parseFileDate(date string, loc *time.Location) (time.Time, RotationInterval) {
+	interval := Daily
+	if len(date) == len(Hourly.layout()) {
+		interval = Hourly
+	}
+	parsed, _ := time.ParseInLocation(interval.layout(), date, loc)
+	return parsed, interval
+}
+
+// matches the names of every file with the given stem, of any date and
+// rotated or not, e.g. 2006-01-02-app_logs.20060102T150405.000000000.csv.gz,
+// capturing the date and the rotation stamp
+func backupPattern(stem string) *regexp.Regexp {
+	name := strings.TrimSuffix(strings.TrimSuffix(stem, ".gz"), ".csv")
+	return regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}(?:T\d{2})?)-` + regexp.QuoteMeta(name) +
+		`(?:\.(\d{8}T\d{6}\.\d{9}))?\.csv(?:\.gz|\.zst)?$`)
+}
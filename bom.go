@@ -0,0 +1,28 @@
+package goutils
+
+import (
+	"bufio"
+	"io"
+)
+
+// UTF-8 byte-order mark
+const utf8BOM = "\xef\xbb\xbf"
+
+// WithUTF8BOM writes a UTF-8 byte-order mark at the start of every new file
+// (including the fresh file after a rotation), so spreadsheets such as Excel
+// read non-ASCII events as UTF-8 instead of a legacy codepage. Files reopened
+// with existing content are left as they are. ParseReader skips the mark.
+func WithUTF8BOM() Option {
+	return func(c *config) {
+		c.utf8BOM = true
+	}
+}
+
+// skipBOM drops a leading byte-order mark
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if mark, _ := br.Peek(len(utf8BOM)); string(mark) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+	return br
+}
@@ -0,0 +1,83 @@
+package goutils
+
+import (
+	"errors"
+	"time"
+)
+
+// WithBuffering buffers up to size bytes per file in memory before writing,
+// trading durability for fewer syscalls. Buffered lines reach the files on
+// Flush, Close, Rotate or when an event hits the flush threshold.
+func WithBuffering(size int) Option {
+	return func(c *config) {
+		c.bufferSize = size
+	}
+}
+
+// WithFlushThreshold flushes the destination buffer right after any event at
+// or above the given severity. Defaults to Critical so everything routed to
+// the error file is written immediately.
+func WithFlushThreshold(severity Severity) Option {
+	return func(c *config) {
+		c.flushThreshold = severity
+	}
+}
+
+// Flush writes any buffered lines to the files.
+func (b *Blogger) Flush() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []error
+	for _, dest := range b.sinks() {
+		errs = append(errs, b.cfg.writeError("flush", dest.path, dest.flush()))
+	}
+	return errors.Join(errs...)
+}
+
+// Sync flushes the buffers and asks the OS to commit both files to stable
+// storage (fsync). Unlike Flush, logged lines survive a machine crash once
+// Sync returns.
+func (b *Blogger) Sync() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []error
+	for _, dest := range b.sinks() {
+		errs = append(errs, b.cfg.writeError("sync", dest.path, dest.sync()))
+	}
+	return errors.Join(errs...)
+}
+
+// WithFlushInterval flushes both buffers every interval so buffered lines
+// reach the files within a bounded delay even when traffic is low. The
+// ticker stops on Close.
+func WithFlushInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.flushInterval = d
+	}
+}
+
+func (b *Blogger) startFlushTicker(interval time.Duration) {
+	b.background.Add(1)
+	go func() {
+		defer b.background.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := b.Flush(); err != nil {
+					b.cfg.onError(err)
+				}
+			case <-b.done:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,101 @@
+package goutils
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a logger for a subsystem that shares the files, routes and
+// write mutex of b but has its own copy of the configuration, so opts only
+// affect the clone, e.g. WithMinSeverity or WithServiceName. Options about
+// how events are filtered and formatted apply; options about destinations,
+// buffering, sampling or rotation are ignored since those stay shared.
+// b keeps owning the files: Close on a clone does nothing and Rotate
+// rotates the files of the original logger, without updating LogsFile and
+// ErrorsFile of the clone.
+func (b *Blogger) Clone(opts ...Option) *Blogger {
+	if b == nil {
+		return nil
+	}
+	root := b
+	if b.root != nil {
+		root = b.root
+	}
+
+	// SetLineFormat swaps the format and sequencer under the write mutex
+	b.mu.Lock()
+	cfg, sequence := b.cfg.derive(opts), b.sequence
+	b.mu.Unlock()
+	return &Blogger{
+		LogsFile:   b.LogsFile,
+		ErrorsFile: b.ErrorsFile,
+		mu:         b.mu,
+		logs:       b.logs,
+		errors:     b.errors,
+		replicas:   b.replicas,
+		routes:     b.routes,
+		mirrors:    b.mirrors,
+		dump:       b.dump,
+
+		subscribers: b.subscribers,
+
+		cfg:    cfg,
+		cipher: b.cipher,
+
+		reservoir: b.reservoir,
+		ring:      b.ring,
+		replay:    b.replay,
+		sequence:  sequence,
+		done:      root.done,
+		root:      root,
+		start:     b.start,
+	}
+}
+
+// derive applies opts to a copy of c, keeping only what can differ between
+// loggers sharing destinations
+func (c config) derive(opts []Option) config {
+	derived := c
+	derived.names.severities = maps.Clone(c.names.severities)
+	derived.names.aliases = maps.Clone(c.names.aliases)
+	derived.names.processTypes = maps.Clone(c.names.processTypes)
+	derived.severityPrefixes = maps.Clone(c.severityPrefixes)
+	derived.errorSeverities = maps.Clone(c.errorSeverities)
+	derived.fields = maps.Clone(c.fields)
+	derived.transforms = slices.Clone(c.transforms)
+	scratch := *c.format // WithColumns and WithDelimiter write here and are dropped
+	derived.format = &scratch
+	if !c.sharedLevel {
+		derived.level = newLevel(c.minSeverity())
+	}
+	for _, opt := range opts {
+		opt(&derived)
+	}
+
+	kept := c
+	format := *c.format
+	kept.format = &format // SetLineFormat on b leaves the clone alone
+	kept.names = derived.names
+	kept.level = derived.level
+	kept.sharedLevel = derived.sharedLevel
+	kept.eventRules = derived.eventRules
+	kept.transforms = derived.transforms
+	kept.maxEventLength = derived.maxEventLength
+	kept.emptyEvent = derived.emptyEvent
+	kept.serviceName = derived.serviceName
+	kept.fields = derived.fields
+	kept.severityPrefixes = derived.severityPrefixes
+	kept.monotonicGuard = derived.monotonicGuard
+	kept.monotonicClamp = derived.monotonicClamp
+	kept.flushThreshold = derived.flushThreshold
+	kept.onError = derived.onError
+	kept.errorSeverities = derived.errorSeverities
+	kept.idGenerator = derived.idGenerator
+	kept.requestSeverity = derived.requestSeverity
+	kept.requestErrorSeverity = derived.requestErrorSeverity
+	kept.exitOnEmergency = derived.exitOnEmergency
+	kept.exitCode = derived.exitCode
+	kept.exit = derived.exit
+	kept.swallowPanics = derived.swallowPanics
+	return kept
+}
@@ -0,0 +1,186 @@
+package goutils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// CompressionAlgo selects how rotated backups are compressed.
+type CompressionAlgo int
+
+const (
+	NoCompression CompressionAlgo = iota
+	Gzip
+	Zstd // needs the zstd build tag, see WithCompressionAlgo
+)
+
+var compressionExtension = map[CompressionAlgo]string{
+	Gzip: ".gz",
+	Zstd: ".zst",
+}
+
+// WithCompressionAlgo compresses the backups produced by rotation and the
+// files finished by WithRotationInterval, the uncompressed file is removed
+// once its compressed copy is complete. Zstd pulls in
+// github.com/klauspost/compress and is only built with -tags zstd, without
+// it NewLogger returns ErrZstdUnavailable for Zstd and ParseLogFile fails
+// on zstd input with the same error.
+func WithCompressionAlgo(algo CompressionAlgo) Option {
+	return func(c *config) {
+		c.compression = algo
+	}
+}
+
+// compressFile returns the path of the compressed copy, or path itself
+// when no compression is configured
+func compressFile(path string, algo CompressionAlgo) (string, error) {
+	ext, ok := compressionExtension[algo]
+	if !ok {
+		return path, nil
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dstPath := path + ext
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	if err := compressTo(dst, src, algo); err != nil {
+		dst.Close()
+		os.Remove(dstPath)
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dstPath)
+		return "", err
+	}
+
+	// only drop the original once the compressed copy is safely written
+	src.Close()
+	if err := os.Remove(path); err != nil {
+		return "", err
+	}
+	return dstPath, nil
+}
+
+func compressTo(dst io.Writer, src io.Reader, algo CompressionAlgo) error {
+	var w io.WriteCloser
+	switch algo {
+	case Zstd:
+		encoder, err := newZstdWriter(dst)
+		if err != nil {
+			return err
+		}
+		w = encoder
+	default:
+		w = gzip.NewWriter(dst)
+	}
+
+	if _, err := io.Copy(w, src); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// WithLiveCompression gzips the active files while they are written, they
+// are named .csv.gz instead of .csv. The compressor is flushed with the
+// files (Flush, the flush threshold and every flush interval, 1 second
+// unless set), so everything up to the last flush can be read back with
+// ParseLogFile while the file is still open. Rotated backups are already
+// compressed and WithCompressionAlgo is ignored.
+func WithLiveCompression() Option {
+	return func(c *config) {
+		c.liveCompression = true
+	}
+}
+
+func (c config) compressing(w io.WriteCloser) io.WriteCloser {
+	if !c.liveCompression {
+		return w
+	}
+	return newLiveGzip(w)
+}
+
+// default flush interval of live compressed files
+const liveFlushInterval = time.Second
+
+// gzip stream over the active file, closing it ends the stream first
+type liveGzip struct {
+	gz *gzip.Writer
+	w  io.WriteCloser
+}
+
+func newLiveGzip(w io.WriteCloser) *liveGzip {
+	return &liveGzip{gz: gzip.NewWriter(w), w: w}
+}
+
+func (l *liveGzip) Write(p []byte) (int, error) {
+	return l.gz.Write(p)
+}
+
+func (l *liveGzip) Flush() error {
+	return l.gz.Flush()
+}
+
+func (l *liveGzip) Close() error {
+	if err := l.gz.Close(); err != nil {
+		l.w.Close()
+		return err
+	}
+	return l.w.Close()
+}
+
+// restart begins a new stream, after the file has been truncated
+func (l *liveGzip) restart() {
+	l.gz.Reset(l.w)
+}
+
+// magic bytes opening a compressed stream
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// decompressed detects gzip and zstd input by its magic bytes, a gzip
+// stream may be unterminated (a live file still being written) in which
+// case it ends at the last flush
+func decompressed(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return unterminatedReader{gz}, nil
+	case bytes.Equal(magic, zstdMagic):
+		return newZstdReader(br)
+	default:
+		return br, nil
+	}
+}
+
+type unterminatedReader struct {
+	io.Reader
+}
+
+func (u unterminatedReader) Read(p []byte) (int, error) {
+	n, err := u.Reader.Read(p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,17 @@
+//go:build !zstd
+
+package goutils
+
+import "io"
+
+// built without the zstd tag, so the root package does not depend on
+// github.com/klauspost/compress
+const zstdAvailable = false
+
+func newZstdWriter(io.Writer) (io.WriteCloser, error) {
+	return nil, ErrZstdUnavailable
+}
+
+func newZstdReader(io.Reader) (io.Reader, error) {
+	return nil, ErrZstdUnavailable
+}
@@ -0,0 +1,27 @@
+//go:build zstd
+
+package goutils
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const zstdAvailable = true
+
+func newZstdWriter(dst io.Writer) (io.WriteCloser, error) {
+	encoder, err := zstd.NewWriter(dst)
+	if err != nil {
+		return nil, err
+	}
+	return encoder, nil
+}
+
+func newZstdReader(r io.Reader) (io.Reader, error) {
+	decoder, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
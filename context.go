@@ -0,0 +1,71 @@
+package goutils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type processKey struct{}
+
+type contextProcess struct {
+	processType ProcessType
+	id          string
+}
+
+// ContextWithProcess returns a copy of ctx carrying the process type and id
+// that LogContext uses for events logged without a ProcessId.
+func ContextWithProcess(ctx context.Context, processType ProcessType, id string) context.Context {
+	return context.WithValue(ctx, processKey{}, contextProcess{processType: processType, id: id})
+}
+
+// an event without a ProcessId takes both its process type and id from ctx,
+// the type alone cannot tell a default OsProcess from an explicit one
+func withContextProcess(ctx context.Context, process LogEvent) LogEvent {
+	if process.ProcessId != "" {
+		return process
+	}
+	if p, ok := ctx.Value(processKey{}).(contextProcess); ok {
+		process.ProcessType = p.processType
+		process.ProcessId = p.id
+	}
+	return process
+}
+
+// WithIDGenerator sets how request ids are generated when none is supplied,
+// by the gRPC interceptors and by ContextWithRequestID. Defaults to 16
+// random bytes in hex.
+func WithIDGenerator(generate func() string) Option {
+	return func(c *config) {
+		if generate != nil {
+			c.idGenerator = generate
+		}
+	}
+}
+
+// ContextWithRequestID is ContextWithProcess for a request, an empty id is
+// replaced by a generated one so correlation ids are always present.
+func (b *Blogger) ContextWithRequestID(ctx context.Context, id string) context.Context {
+	var cfg config
+	if b != nil {
+		cfg = b.cfg
+	}
+	return ContextWithProcess(ctx, RequestProcess, cfg.requestID(id))
+}
+
+func (c config) requestID(id string) string {
+	if id != "" {
+		return id
+	}
+	if c.idGenerator != nil {
+		return c.idGenerator()
+	}
+	return randomID()
+}
+
+func randomID() string {
+	var id [16]byte
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,102 @@
+package goutils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ValidateLogger runs the checks NewLogger would with the same arguments
+// without creating the directory or the dated files, e.g. in a startup
+// health check. The options are validated, a missing directory must be
+// creatable under its nearest existing parent and existing files must be
+// writable, with the configured columns if they declare them. Write
+// permission is probed with a temporary file removed right away. Every
+// WithMirrorDirectory gets the same checks, so a health check notices a
+// lost mount that NewLogger would only report to OnError. Destinations
+// from WithWriterFactory are not checked.
+func ValidateLogger(logDirectory string, logFilename string, errorFilename string, opts ...Option) error {
+	cfg := newConfig(opts)
+	if _, err := cfg.check(); err != nil {
+		return err
+	}
+	if cfg.writerFactory != nil {
+		return nil
+	}
+
+	errorFilename = cfg.resolveErrorFilename(logFilename, errorFilename)
+	if err := cfg.checkDestination(logDirectory, logFilename, errorFilename); err != nil {
+		return err
+	}
+	for _, dir := range cfg.mirrorDirectories {
+		if err := cfg.checkDestination(dir, logFilename, errorFilename); err != nil {
+			return fmt.Errorf("mirror directory: %w", err)
+		}
+	}
+	return nil
+}
+
+// the checks of ValidateLogger for the files NewLogger opens in dir
+func (c config) checkDestination(dir string, logFilename string, errorFilename string) error {
+	if err := checkDirectory(dir); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrLogDirCreate, dir, err)
+	}
+
+	logsFilepath, errorsFilepath := outputPaths(c, c.fileDate(), dir, logFilename, errorFilename)
+	paths := []string{logsFilepath}
+	if !c.oneFile() {
+		paths = append(paths, errorsFilepath)
+	}
+	for _, path := range paths {
+		if err := c.checkExistingFile(path); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrLogFileOpen, path, err)
+		}
+	}
+	return nil
+}
+
+// the directory, or its nearest existing parent when missing, must be a
+// directory files can be created in
+func checkDirectory(dir string) error {
+	existing := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(existing)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", existing)
+			}
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return err
+		}
+		existing = parent
+	}
+
+	probe, err := os.CreateTemp(existing, ".goutils-probe-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// today's file may already exist, e.g. after a restart, and must then
+// declare the configured columns if it has a schema header
+func (c config) checkExistingFile(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return c.checkSchema(file)
+}
@@ -0,0 +1,90 @@
+package goutils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+)
+
+// severities written to the debug dump
+var dumpSeverities = []Severity{Debug, Trace}
+
+// scratch file toggled at runtime, shared by a logger and its clones
+type debugDump struct {
+	active atomic.Bool // read without the write mutex by IsEnabled
+	sink   *sink       // guarded by the write mutex, nil when disabled
+}
+
+func (d *debugDump) accepts(severity Severity) bool {
+	return d != nil && d.active.Load() && slices.Contains(dumpSeverities, severity)
+}
+
+// EnableDebugDump additionally writes Debug and Trace events to the file at
+// path until DisableDebugDump, e.g. to capture verbose lines during an
+// incident without adding them to the log files. They are written even
+// below WithMinSeverity, in the same format as the files. The file is
+// created if missing and appended to otherwise; enabling again switches to
+// the new path. The dump is unbuffered, never rotated and closed by Close.
+func (b *Blogger) EnableDebugDump(path string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.logs.closed {
+		return ErrLoggerClosed
+	}
+
+	file, err := openLogFile(path)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrLogFileOpen, path, err)
+	}
+	dest, err := newSink(&namedWriter{WriteCloser: file, name: path, file: file}, 0, "", dumpSeverities...)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	previous := b.dump.sink
+	b.dump.sink = dest
+	b.dump.active.Store(true)
+	if previous != nil {
+		return previous.close()
+	}
+	return nil
+}
+
+// DisableDebugDump stops writing to the debug dump and closes it, remove
+// deletes the file as well. It does nothing when no dump is enabled.
+func (b *Blogger) DisableDebugDump(remove bool) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dump.stop(remove)
+}
+
+// stop must be called with the write mutex held
+func (d *debugDump) stop(remove bool) error {
+	if d == nil || d.sink == nil {
+		return nil
+	}
+	d.active.Store(false)
+	dest := d.sink
+	d.sink = nil
+	err := dest.close()
+	if remove {
+		err = errors.Join(err, os.Remove(dest.path))
+	}
+	return err
+}
+
+// writeDump must be called with the write mutex held
+func (b *Blogger) writeDump(severity Severity, line string) error {
+	if !b.dump.accepts(severity) || b.dump.sink == nil {
+		return nil
+	}
+	return b.cfg.writeError("write", b.dump.sink.path, b.dump.sink.write(line))
+}
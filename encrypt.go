@@ -0,0 +1,99 @@
+package goutils
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// WithEncryption encrypts every line with AES-GCM before it reaches the file.
+// The key must be 16, 24 or 32 bytes long (AES-128/192/256).
+//
+// Each line is written as base64(nonce || ciphertext) so files stay line
+// oriented, but they are no longer plain CSV: grep and other text tools can
+// only be used on the output of DecryptLogFile.
+func WithEncryption(key []byte) Option {
+	return func(c *config) {
+		c.encryptionKey = append([]byte(nil), key...)
+	}
+}
+
+func newLineCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptLine seals the line with a fresh random nonce prepended to it
+func encryptLine(aead cipher.AEAD, line string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(line), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptLogFile decrypts a file written with WithEncryption and writes the
+// plain lines to out, one per line.
+func DecryptLogFile(path string, key []byte, out io.Writer) error {
+	aead, err := newLineCipher(key)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		encoded := strings.TrimSuffix(scanner.Text(), "\r")
+		if lineNumber == 1 && strings.HasPrefix(encoded, utf8BOM) {
+			// written in clear, kept for the decrypted copy
+			if _, err := io.WriteString(out, utf8BOM); err != nil {
+				return err
+			}
+			encoded = strings.TrimPrefix(encoded, utf8BOM)
+		}
+		if encoded == "" {
+			continue
+		}
+		if _, _, ok := ParseSchemaHeader(encoded); ok {
+			// written in clear
+			if _, err := fmt.Fprintln(out, encoded); err != nil {
+				return err
+			}
+			continue
+		}
+
+		sealed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if len(sealed) < aead.NonceSize() {
+			return fmt.Errorf("line %d: record too short", lineNumber)
+		}
+		nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+		plain, err := aead.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		if _, err := fmt.Fprintln(out, string(plain)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,61 @@
+package goutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// environment variables read by NewLoggerFromEnv
+const (
+	EnvLogDir       = "LOG_DIR"
+	EnvLogName      = "LOG_NAME"
+	EnvLogErrorName = "LOG_ERROR_NAME"
+	EnvLogLevel     = "LOG_LEVEL"
+)
+
+// defaults used when the environment variables are unset or empty
+const (
+	DefaultLogDir  = "logs" // relative to the current working directory
+	DefaultLogName = "app"
+)
+
+// NewLoggerFromEnv creates a logger configured by environment variables:
+//
+//	LOG_DIR         directory of the files, default "logs" in the working directory
+//	LOG_NAME        name of the log file, default "app"
+//	LOG_ERROR_NAME  name of the error file, default LOG_NAME + "_errors"
+//	LOG_LEVEL       minimum severity name (see ParseSeverity), default everything
+//
+// Options passed explicitly are applied after the environment and win over it.
+func NewLoggerFromEnv(opts ...Option) (*Blogger, error) {
+	logDirectory := os.Getenv(EnvLogDir)
+	if logDirectory == "" {
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		logDirectory = filepath.Join(workingDir, DefaultLogDir)
+	}
+
+	logFilename := os.Getenv(EnvLogName)
+	if logFilename == "" {
+		logFilename = DefaultLogName
+	}
+
+	errorFilename := os.Getenv(EnvLogErrorName)
+	if errorFilename == "" {
+		errorFilename = logFilename + "_errors"
+	}
+
+	var envOpts []Option
+	if level := os.Getenv(EnvLogLevel); level != "" {
+		severity, err := ParseSeverity(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvLogLevel, err)
+		}
+		envOpts = append(envOpts, WithMinSeverity(severity))
+	}
+
+	return NewLogger(logDirectory, logFilename, errorFilename, append(envOpts, opts...)...)
+}
@@ -0,0 +1,140 @@
+package goutils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// sentinel errors, match them with errors.Is:
+//
+//   - NewLogger: ErrLogDirCreate, ErrLogFileOpen (also wrapping
+//     ErrSchemaMismatch or ErrFileLocked), ErrInvalidDelimiter,
+//     ErrZstdUnavailable
+//   - Close: ErrCloseTimeout, ErrDrainIncomplete, ErrLoggerClosed when
+//     called again
+//   - Flush and Sync: ErrWriteFailed, also wrapping ErrLoggerClosed once
+//     closed
+//   - Rotate: ErrLoggerClosed once closed
+//   - functions given to WithOnError: ErrWriteFailed for lines that could
+//     not be written (wrapping ErrLoggerClosed after Close), ErrInvalidEvent
+//     for events rejected by WithEventValidation or WithEmptyEventPolicy
+//
+// Log itself never returns an error.
+var (
+	ErrLogDirCreate = errors.New("cannot create log directory")
+	ErrLogFileOpen  = errors.New("cannot open log file")
+
+	ErrSchemaMismatch = errors.New("existing file has different columns")
+	ErrFileLocked     = errors.New("file is locked by another logger")
+
+	ErrInvalidDelimiter = errors.New("invalid field delimiter")
+	ErrZstdUnavailable  = errors.New("zstd support not built in, build with -tags zstd")
+	ErrCloseTimeout     = errors.New("background goroutines did not stop")
+	ErrDrainIncomplete  = errors.New("sampled events abandoned on close")
+	ErrInvalidEvent     = errors.New("invalid log event")
+	ErrLoggerClosed     = errors.New("logger is closed")
+	ErrWriteFailed      = errors.New("cannot write log file")
+)
+
+// WriteError is passed to the WithOnError function when the logger fails to
+// write, flush or sync one of its files, or to open a mirror directory.
+type WriteError struct {
+	Op       string   // "write", "flush", "sync" or "open"
+	Path     string   // file or writer name
+	Severity Severity // how serious the failure is, see WithErrorSeverities
+	Err      error
+
+	// set for a failed write, Event is the whole event even when only one of
+	// its split lines failed and nil for sampled lines and the logger's own
+	EventSeverity Severity
+	Event         *LogEvent
+}
+
+func (e *WriteError) Error() string {
+	if e.Op == "open" {
+		return fmt.Sprintf("error while opening %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("error while %s to %s: %v", e.op(), e.Path, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrWriteFailed, the cause is matched through Unwrap.
+func (e *WriteError) Is(target error) bool {
+	return target == ErrWriteFailed
+}
+
+// Fatal reports whether the failure is Critical or worse, e.g. a full disk,
+// so that lines will keep being lost until someone intervenes.
+func (e *WriteError) Fatal() bool {
+	return e.Severity.AtLeast(Critical)
+}
+
+func (e *WriteError) op() string {
+	switch e.Op {
+	case "write":
+		return "writing"
+	case "flush":
+		return "flushing"
+	case "sync":
+		return "syncing"
+	}
+	return e.Op
+}
+
+// a default classification, checked in order after the WithErrorSeverities
+// overrides, see defaultErrorSeverities; other errors are Critical
+type errorSeverity struct {
+	err      error
+	severity Severity
+}
+
+// WithErrorSeverities classifies the errors matching (errors.Is) the given
+// targets with the given severity in the WriteError passed to WithOnError,
+// overriding the defaults: Critical for a full disk, a read-only file system
+// or an I/O error, Notice for transient errors such as EAGAIN, and Critical
+// for anything else.
+func WithErrorSeverities(severities map[error]Severity) Option {
+	return func(c *config) {
+		if c.errorSeverities == nil {
+			c.errorSeverities = make(map[error]Severity, len(severities))
+		}
+		for target, severity := range severities {
+			c.errorSeverities[target] = severity
+		}
+	}
+}
+
+// writeError classifies err, nil when there is nothing to report
+func (c config) writeError(op string, path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &WriteError{Op: op, Path: path, Severity: c.errorSeverity(err), Err: err}
+}
+
+// lineError is the writeError of a line that could not be written
+func (c config) lineError(path string, err error, severity Severity, event *LogEvent) error {
+	return &WriteError{Op: "write", Path: path, Severity: c.errorSeverity(err), Err: err, EventSeverity: severity, Event: event}
+}
+
+func (c config) errorSeverity(err error) Severity {
+	// the most severe override wins when several match
+	severity, matched := Trace, false
+	for target, s := range c.errorSeverities {
+		if errors.Is(err, target) && (!matched || s.MoreSevereThan(severity)) {
+			severity, matched = s, true
+		}
+	}
+	if matched {
+		return severity
+	}
+	for _, d := range defaultErrorSeverities {
+		if errors.Is(err, d.err) {
+			return d.severity
+		}
+	}
+	return Critical
+}
@@ -0,0 +1,17 @@
+//go:build !plan9
+
+package goutils
+
+import (
+	"os"
+	"syscall"
+)
+
+var defaultErrorSeverities = []errorSeverity{
+	{syscall.ENOSPC, Critical},
+	{syscall.EROFS, Critical},
+	{syscall.EIO, Critical},
+	{syscall.EAGAIN, Notice},
+	{syscall.EINTR, Notice},
+	{os.ErrDeadlineExceeded, Notice},
+}
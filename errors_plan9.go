@@ -0,0 +1,15 @@
+//go:build plan9
+
+package goutils
+
+import (
+	"os"
+	"syscall"
+)
+
+// plan9 has no ENOSPC, EROFS or EAGAIN
+var defaultErrorSeverities = []errorSeverity{
+	{syscall.EIO, Critical},
+	{syscall.EINTR, Notice},
+	{os.ErrDeadlineExceeded, Notice},
+}
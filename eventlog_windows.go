@@ -0,0 +1,65 @@
+//go:build windows
+
+package goutils
+
+import "golang.org/x/sys/windows/svc/eventlog"
+
+// EventLogType is the Windows Event Log level a severity is reported with.
+type EventLogType int
+
+const (
+	EventLogInfo EventLogType = iota
+	EventLogWarning
+	EventLogError
+)
+
+// event id attached to every entry, the event source has a single message
+const eventLogID = 1
+
+// EventLogType maps Emergency, Alert and Critical to Error, Notice to
+// Warning and Debug and Trace to Info.
+func (severity Severity) EventLogType() EventLogType {
+	switch {
+	case severity.AtLeast(Critical):
+		return EventLogError
+	case severity.AtLeast(Notice):
+		return EventLogWarning
+	default:
+		return EventLogInfo
+	}
+}
+
+// WithEventLog also reports every line to the Windows Event Log under the
+// given source, alongside the files. The source should be registered
+// beforehand (e.g. with eventlog.InstallAsEventCreate, which needs
+// administrator rights), otherwise entries show up without a message file.
+func WithEventLog(source string) Option {
+	return func(c *config) {
+		c.mirrors = append(c.mirrors, func() (mirror, error) {
+			events, err := eventlog.Open(source)
+			if err != nil {
+				return nil, err
+			}
+			return eventLogMirror{log: events}, nil
+		})
+	}
+}
+
+type eventLogMirror struct {
+	log *eventlog.Log
+}
+
+func (m eventLogMirror) writeLine(severity Severity, line string) error {
+	switch severity.EventLogType() {
+	case EventLogError:
+		return m.log.Error(eventLogID, line)
+	case EventLogWarning:
+		return m.log.Warning(eventLogID, line)
+	default:
+		return m.log.Info(eventLogID, line)
+	}
+}
+
+func (m eventLogMirror) Close() error {
+	return m.log.Close()
+}
@@ -0,0 +1,43 @@
+package goutils
+
+import (
+	"fmt"
+	"os"
+)
+
+// WithExitOnEmergency terminates the process with the given exit code after
+// an Emergency event, once its line has been flushed and synced to disk.
+// Emergency events are never sampled with this option.
+func WithExitOnEmergency(code int) Option {
+	return func(c *config) {
+		c.exitOnEmergency = true
+		c.exitCode = code
+	}
+}
+
+// WithExitFunc replaces os.Exit as the way WithExitOnEmergency terminates
+// the process, e.g. to assert on it in tests.
+func WithExitFunc(exit func(code int)) Option {
+	return func(c *config) {
+		if exit != nil {
+			c.exit = exit
+		}
+	}
+}
+
+func (c config) exitsOn(severity Severity) bool {
+	return c.exitOnEmergency && severity == Emergency
+}
+
+// exitAfterEmergency runs once the Emergency line is written, a failed
+// sync is reported but never prevents the exit
+func (b *Blogger) exitAfterEmergency() {
+	if err := b.Sync(); err != nil {
+		b.cfg.onError(fmt.Errorf("error while syncing before exit: %w", err))
+	}
+	exit := b.cfg.exit
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(b.cfg.exitCode)
+}
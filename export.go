@@ -0,0 +1,127 @@
+package goutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// QueryOptions filters the records read from log files, zero fields match
+// every record.
+type QueryOptions struct {
+	Severities   []Severity    // any of them
+	ProcessTypes []ProcessType // any of them
+	ProcessId    string
+	Since        time.Time // inclusive
+	Until        time.Time // exclusive
+	Contains     string    // substring of the event
+
+	// how the files were written, see ParseReader
+	ParseOptions []ParseOption
+}
+
+func (o QueryOptions) matches(record LogRecord) bool {
+	switch {
+	case len(o.Severities) > 0 && !slices.Contains(o.Severities, record.Severity):
+		return false
+	case len(o.ProcessTypes) > 0 && !slices.Contains(o.ProcessTypes, record.ProcessType):
+		return false
+	case o.ProcessId != "" && record.ProcessId != o.ProcessId:
+		return false
+	case !o.Since.IsZero() && record.Timestamp.Before(o.Since):
+		return false
+	case !o.Until.IsZero() && !record.Timestamp.Before(o.Until):
+		return false
+	case o.Contains != "" && !strings.Contains(record.Event, o.Contains):
+		return false
+	}
+	return true
+}
+
+// JSON object written for each record, names are the default ones
+type jsonRecord struct {
+	Severity    string            `json:"severity"`
+	Timestamp   time.Time         `json:"timestamp"`
+	ProcessType string            `json:"process_type"`
+	ProcessId   string            `json:"process_id"`
+	Event       string            `json:"event"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+// ExportJSONArray writes the records of the files at paths matching opts to
+// out as a single JSON array, e.g. for a browser based viewer. Records are
+// read and written one at a time so the files are never held in memory, an
+// error part way leaves the array unterminated.
+func ExportJSONArray(paths []string, out io.Writer, opts QueryOptions) error {
+	if _, err := io.WriteString(out, "["); err != nil {
+		return err
+	}
+	written := 0
+	for _, path := range paths {
+		if err := exportFile(path, out, opts, &written); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	_, err := io.WriteString(out, "]")
+	return err
+}
+
+func exportFile(path string, out io.Writer, opts QueryOptions, written *int) error {
+	return eachRecord(path, opts.ParseOptions, func(record LogRecord) error {
+		if !opts.matches(record) {
+			return nil
+		}
+
+		object, err := json.Marshal(jsonRecord{
+			Severity:    severityName[record.Severity],
+			Timestamp:   record.Timestamp,
+			ProcessType: processTypeName[record.ProcessType],
+			ProcessId:   record.ProcessId,
+			Event:       record.Event,
+			Extra:       record.Extra,
+		})
+		if err != nil {
+			return err
+		}
+		if *written > 0 {
+			object = append([]byte{','}, object...)
+		}
+		if _, err := out.Write(object); err != nil {
+			return err
+		}
+		*written++
+		return nil
+	})
+}
+
+// eachRecord streams the records of the file at path to fn, stopping at
+// the first error
+func eachRecord(path string, opts []ParseOption, fn func(LogRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := newRecordReader(file, opts)
+	if err != nil {
+		return err
+	}
+	for {
+		record, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
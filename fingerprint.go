@@ -0,0 +1,39 @@
+package goutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
+
+// volatile parts of an event text, UUIDs first since they contain numbers
+var (
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	numberPattern = regexp.MustCompile(`\d+`)
+)
+
+// NormalizeEvent replaces UUIDs and numbers in text with placeholders, so
+// events differing only in such values read the same. It is the normalizer
+// used by Fingerprint.
+func NormalizeEvent(text string) string {
+	text = uuidPattern.ReplaceAllString(text, "<uuid>")
+	return numberPattern.ReplaceAllString(text, "<n>")
+}
+
+// Fingerprint returns a stable hash of the normalized event text, e.g. to
+// group similar errors together in analysis. The process type and id are
+// not part of it.
+func Fingerprint(event LogEvent) string {
+	return FingerprintWith(event, NormalizeEvent)
+}
+
+// FingerprintWith is Fingerprint with normalize applied to the event text
+// instead of NormalizeEvent, nil hashes the text as it is.
+func FingerprintWith(event LogEvent, normalize func(string) string) string {
+	text := event.Event
+	if normalize != nil {
+		text = normalize(text)
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
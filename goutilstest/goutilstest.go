@@ -0,0 +1,184 @@
+// Package goutilstest provides helpers to test code using the goutils logger.
+package goutilstest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+var severities = []goutils.Severity{
+	goutils.Emergency, goutils.Alert, goutils.Critical, goutils.Notice, goutils.Debug, goutils.Trace,
+}
+
+// Routed is what AssertRouting needs from a logger, *goutils.Blogger and
+// *Capture both satisfy it.
+type Routed interface {
+	Log(severity goutils.Severity, process goutils.LogEvent)
+	MinSeverity() goutils.Severity
+	Files() []goutils.FileInfo
+	Flush() error
+}
+
+var (
+	_ Routed = (*goutils.Blogger)(nil)
+	_ Routed = (*Capture)(nil)
+)
+
+// Capture is a logger writing to two in-memory buffers instead of files.
+type Capture struct {
+	*goutils.Blogger
+
+	mu      sync.Mutex
+	buffers map[string]*buffer // by destination name
+}
+
+const (
+	captureLogs   = "capture_logs"
+	captureErrors = "capture_errors"
+)
+
+// CaptureLogger returns a logger whose standard and error output are kept in
+// memory, options are applied as with goutils.NewLogger. The logger is
+// closed when the test ends.
+func CaptureLogger(t testing.TB, opts ...goutils.Option) *Capture {
+	t.Helper()
+
+	c := &Capture{buffers: make(map[string]*buffer)}
+	opts = append(opts, goutils.WithWriterFactory(c.open))
+	logger, err := goutils.NewLogger("capture", captureLogs, captureErrors, opts...)
+	if err != nil {
+		t.Fatalf("capture logger was not initialised: %v", err)
+	}
+	c.Blogger = logger
+	t.Cleanup(func() {
+		if err := logger.Close(); err != nil {
+			t.Errorf("capture logger was not closed: %v", err)
+		}
+	})
+	return c
+}
+
+// rotation reopens the same name, keep appending to the same buffer
+func (c *Capture) open(name string) (io.WriteCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.buffers[name]; !ok {
+		c.buffers[name] = &buffer{}
+	}
+	return c.buffers[name], nil
+}
+
+func (c *Capture) content(name string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	buf, ok := c.buffers[name]
+	if !ok {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// Logs returns everything written to the active standard output so far,
+// the destination reported by Paths.
+func (c *Capture) Logs() string {
+	logs, _ := c.Paths()
+	content, _ := c.content(logs)
+	return content
+}
+
+// Errors returns everything written to the active error output so far, the
+// destination reported by Paths.
+func (c *Capture) Errors() string {
+	_, errors := c.Paths()
+	content, _ := c.content(errors)
+	return content
+}
+
+// AssertRouting logs one marker event per enabled severity and fails the
+// test unless every marker landed only in the files reported for its
+// severity by Files. Severities below MinSeverity are skipped, they are
+// never written to the files.
+func AssertRouting(t testing.TB, logger Routed) {
+	t.Helper()
+
+	markers := make(map[goutils.Severity]string, len(severities))
+	for _, severity := range severities {
+		if !severity.AtLeast(logger.MinSeverity()) {
+			continue
+		}
+		markers[severity] = fmt.Sprintf("goutilstest routing marker %d", severity)
+		logger.Log(severity, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "goutilstest", Event: markers[severity]})
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("could not flush logger: %v", err)
+	}
+
+	// files sharing a path share their severities as well
+	expected := make(map[string]map[goutils.Severity]bool)
+	for _, info := range logger.Files() {
+		if expected[info.Path] == nil {
+			expected[info.Path] = make(map[goutils.Severity]bool)
+		}
+		for _, severity := range info.Severities {
+			expected[info.Path][severity] = true
+		}
+	}
+
+	for path, routed := range expected {
+		content, err := readDestination(logger, path)
+		if err != nil {
+			t.Fatalf("could not read %s: %v", path, err)
+		}
+		for _, severity := range severities {
+			marker, ok := markers[severity]
+			if !ok {
+				continue
+			}
+			found := strings.Contains(content, marker)
+			if routed[severity] && !found {
+				t.Errorf("%s event missing from %s", severity.ToString(), path)
+			}
+			if !routed[severity] && found {
+				t.Errorf("%s event leaked into %s", severity.ToString(), path)
+			}
+		}
+	}
+}
+
+func readDestination(logger Routed, path string) (string, error) {
+	if c, ok := logger.(interface{ content(string) (string, bool) }); ok {
+		if content, ok := c.content(path); ok {
+			return content, nil
+		}
+	}
+	content, err := os.ReadFile(path)
+	return string(content), err
+}
+
+// concurrency safe in-memory destination, closing is a no-op so content
+// stays readable after the logger is closed
+type buffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *buffer) Close() error { return nil }
+
+func (b *buffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
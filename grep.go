@@ -0,0 +1,46 @@
+package goutils
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Grep streams the records of the files at paths, compressed or not, and
+// calls out with those whose field matches pattern, so a delimiter or a
+// severity name inside an event cannot produce a false match as it would
+// with a plain grep of the lines. Severities and process types are matched
+// by their default names, timestamps in RFC 3339 and the service, sequence
+// and uptime columns by their value in Extra, which needs a header naming
+// them. opts tell how the files were written, see ParseReader.
+func Grep(paths []string, field Column, pattern *regexp.Regexp, out func(LogRecord), opts ...ParseOption) error {
+	for _, path := range paths {
+		err := eachRecord(path, opts, func(record LogRecord) error {
+			if pattern.MatchString(recordField(record, field)) {
+				out(record)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func recordField(record LogRecord, column Column) string {
+	switch column {
+	case SeverityColumn:
+		return severityName[record.Severity]
+	case TimestampColumn:
+		return record.Timestamp.Format(time.RFC3339)
+	case ProcessTypeColumn:
+		return processTypeName[record.ProcessType]
+	case ProcessIdColumn:
+		return record.ProcessId
+	case EventColumn:
+		return record.Event
+	default:
+		return record.Extra[column.ToString()]
+	}
+}
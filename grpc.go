@@ -0,0 +1,83 @@
+package goutils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key read by the interceptors
+// to fill the ProcessId of the logged request, calls without it get an id
+// from the logger's ID generator.
+const RequestIDMetadataKey = "x-request-id"
+
+// UnaryServerInterceptor logs method, status code and latency of every unary
+// call. The handler context carries the request id for LogContext. The
+// interceptor of a nil logger only calls the handler.
+func (b *Blogger) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if b == nil {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		id := b.cfg.requestID(requestIDFromMetadata(ctx))
+		resp, err := handler(ContextWithProcess(ctx, RequestProcess, id), req)
+		b.logRPC(id, info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs method, status code and latency of every stream
+// once the handler returns. The Context of the stream given to the handler
+// carries the request id for LogContext. The interceptor of a nil logger only
+// calls the handler.
+func (b *Blogger) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if b == nil {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		id := b.cfg.requestID(requestIDFromMetadata(ss.Context()))
+		err := handler(srv, processStream{ServerStream: ss, ctx: ContextWithProcess(ss.Context(), RequestProcess, id)})
+		b.logRPC(id, info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+// stream handed to the handler, its context carries the request id
+type processStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s processStream) Context() context.Context {
+	return s.ctx
+}
+
+func (b *Blogger) logRPC(id string, method string, err error, latency time.Duration) {
+	severity := b.cfg.requestSeverity
+	if err != nil {
+		severity = b.cfg.requestErrorSeverity
+	}
+
+	b.Log(severity, LogEvent{
+		ProcessType: RequestProcess,
+		ProcessId:   id,
+		Event:       fmt.Sprintf("method=%s code=%s latency=%s", method, status.Code(err), latency),
+	})
+}
+
+func requestIDFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package goutils
+
+import "sync/atomic"
+
+// WithSharedLevel backs the minimum severity with level, so SetMinSeverity
+// on any logger given the same level (or a store to it) changes them all at
+// once, e.g. from an admin console. The current value of level applies,
+// store a Severity in it beforehand. Clones keep sharing it. Replaces
+// WithMinSeverity.
+func WithSharedLevel(level *atomic.Int32) Option {
+	return func(c *config) {
+		if level != nil {
+			c.level = level
+			c.sharedLevel = true
+		}
+	}
+}
+
+func newLevel(severity Severity) *atomic.Int32 {
+	level := new(atomic.Int32)
+	level.Store(int32(severity))
+	return level
+}
+
+func (c config) minSeverity() Severity {
+	if c.level == nil {
+		return Trace
+	}
+	return Severity(c.level.Load())
+}
+
+// SetMinSeverity changes the least important severity written, it is safe
+// to call while other goroutines log. A clone has its own level unless it
+// was shared with WithSharedLevel.
+func (b *Blogger) SetMinSeverity(severity Severity) {
+	if b == nil {
+		return
+	}
+	b.cfg.level.Store(int32(severity))
+}
+
+// MinSeverity returns the least important severity currently written.
+func (b *Blogger) MinSeverity() Severity {
+	if b == nil {
+		return Trace
+	}
+	return b.cfg.minSeverity()
+}
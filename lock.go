@@ -0,0 +1,12 @@
+package goutils
+
+// WithExclusiveLock takes an advisory lock (flock, LockFileEx on Windows) on
+// every local file the logger opens, including after rotations, so a second
+// process pointed at the same directory and names fails with ErrFileLocked
+// instead of interleaving its lines. The lock is released when the file is
+// closed. On platforms without file locking the files are opened unlocked.
+func WithExclusiveLock() Option {
+	return func(c *config) {
+		c.exclusiveLock = true
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package goutils
+
+import "os"
+
+// no file locking on this platform, the file is used unlocked
+func lockFile(file *os.File) error {
+	return nil
+}
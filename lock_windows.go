@@ -0,0 +1,21 @@
+//go:build windows
+
+package goutils
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Windows locks are mandatory, the locked byte lies far past the end of the
+// file so other processes can still read the lines
+func lockFile(file *os.File) error {
+	overlapped := windows.Overlapped{Offset: ^uint32(0), OffsetHigh: 0x7fffffff}
+	err := windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &overlapped)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return ErrFileLocked
+	}
+	return err
+}
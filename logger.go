@@ -1,13 +1,21 @@
 package goutils
 
 import (
+	"context"
+	"crypto/cipher"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
 // setup severities
@@ -35,6 +43,37 @@ func (severity Severity) ToString() string {
 	return severityName[severity]
 }
 
+// Level is an alias of Severity for callers used to the term.
+type Level = Severity
+
+// canonical ordering, higher is more severe, so comparisons never depend
+// on the iota values above; unknown severities rank below Trace
+var severityPriority = map[Severity]int{
+	Emergency: 5,
+	Alert:     4,
+	Critical:  3,
+	Notice:    2,
+	Debug:     1,
+	Trace:     0,
+}
+
+func (severity Severity) priority() int {
+	if p, ok := severityPriority[severity]; ok {
+		return p
+	}
+	return -1
+}
+
+// AtLeast reports whether severity is as severe as other or more.
+func (severity Severity) AtLeast(other Severity) bool {
+	return severity.priority() >= other.priority()
+}
+
+// MoreSevereThan reports whether severity is strictly more severe than other.
+func (severity Severity) MoreSevereThan(other Severity) bool {
+	return severity.priority() > other.priority()
+}
+
 // setup logger
 type ProcessType int
 
@@ -54,6 +93,31 @@ func (p ProcessType) ToString() string {
 	return processTypeName[p]
 }
 
+// display names of a single logger, the package maps above act as a
+// read-only base and are never mutated so loggers cannot affect each other
+type names struct {
+	severities   map[Severity]string
+	aliases      map[Severity]string // written instead of severities
+	processTypes map[ProcessType]string
+}
+
+func (n names) severity(severity Severity) string {
+	if alias, ok := n.aliases[severity]; ok {
+		return alias
+	}
+	if name, ok := n.severities[severity]; ok {
+		return name
+	}
+	return severityName[severity]
+}
+
+func (n names) processType(p ProcessType) string {
+	if name, ok := n.processTypes[p]; ok {
+		return name
+	}
+	return processTypeName[p]
+}
+
 type LogEvent struct {
 	ProcessType ProcessType
 	ProcessId   string
@@ -69,97 +133,749 @@ type Blogger struct {
 	ErrorsFile *os.File
 	LogsFile   *os.File
 
-	errLogger *log.Logger // includes severities 0-2
-	stdLogger *log.Logger // includes severities 3-5
+	mu       sync.Locker        // guards writes to both sinks, may be shared
+	errors   *sink              // includes severities 0-2
+	logs     *sink              // includes severities 3-5
+	replicas []*sink            // copies in mirror directories, written with their primary
+	routes   map[Severity]*sink // severities pinned to their own writer
+	mirrors  []mirror           // receive every line besides the files
+	dump     *debugDump         // extra file for Debug and Trace, toggled at runtime
+
+	subscribers *subscribers // channels of Subscribe
+
+	cfg    config
+	cipher cipher.AEAD // nil unless encryption is enabled
+
+	hooks sync.WaitGroup // rotation hooks still running
+
+	reservoir  *reservoir             // nil unless reservoir sampling is enabled
+	ring       *ringBuffer[string]    // nil unless recent lines are kept in memory
+	replay     *ringBuffer[LogRecord] // nil unless recent events are kept in memory
+	done       chan struct{}          // closed by Close to stop background goroutines
+	background sync.WaitGroup         // background goroutines still running
+
+	drainAbandoned atomic.Uint64   // sampled lines Close gave up on
+	closed         atomic.Bool     // set by the first Close
+	closing        context.Context // given to Shutdown, bounds the reservoir drain
+
+	lastStamp time.Time  // latest line timestamp, kept by the monotonic guard
+	sequence  *sequencer // nil unless lines are numbered within their second
+	date      string     // in the filenames of the files being written
+
+	drops   dropCounters
+	counts  severityCounts
+	written writeCounters
+	start   time.Time // construction time, used for the uptime
+
+	root *Blogger // logger owning the files, nil unless cloned
 }
 
-func NewLogger(logDirectory string, logFilename string, errorFilename string) (*Blogger, error) {
-	if errorFilename == "" {
-		errorFilename = logFilename
+// guards the public contract against signature drift
+var _ io.Closer = (*Blogger)(nil)
+
+// lifecycle events the logger writes itself, as OsProcess events carrying
+// the process id; match them instead of hard-coding their text
+const (
+	InitSeverity Severity = Trace
+	InitEvent             = "Logger initialised successfully"
+
+	CloseEventPrefix = "Logger closed with uptime " // see WithLifecycleLogs
+)
+
+// formats lines logged on a nil *Blogger, which are sent to stderr with
+// the default names
+var nilLogger = &Blogger{}
+
+func NewLogger(logDirectory string, logFilename string, errorFilename string, opts ...Option) (*Blogger, error) {
+	cfg := newConfig(opts)
+	lineCipher, err := cfg.check()
+	if err != nil {
+		return nil, err
 	}
 
-	logsFile, errorsFile, err := openOutputFiles(logDirectory, logFilename, errorFilename)
+	errorFilename = cfg.resolveErrorFilename(logFilename, errorFilename)
+
+	date := cfg.fileDate()
+	logsWriter, errorsWriter, err := openOutputFiles(cfg, date, logDirectory, logFilename, errorFilename)
 	if err != nil {
 		return nil, err
 	}
+	writers := []*namedWriter{logsWriter, errorsWriter}
+	logsSeverities := []Severity{Notice, Debug, Trace}
+	if cfg.oneFile() {
+		writers = writers[:1]
+		logsSeverities = []Severity{Emergency, Alert, Critical, Notice, Debug, Trace}
+	}
 
-	logger := Blogger{
-		LogsFile:   logsFile,
-		ErrorsFile: errorsFile,
-		// new logger can be directly initialised and assigned to a struct
-		stdLogger: log.New(logsFile, "", 0),
-		errLogger: log.New(errorsFile, "", 0),
+	logsSink, err := newSink(logsWriter, cfg.bufferSize, cfg.header(), cfg.unrouted(logsSeverities...)...)
+	if err != nil {
+		closeWriters(writers...)
+		return nil, err
 	}
+	errorsSink := logsSink
+	if !cfg.oneFile() {
+		errorsSink, err = newSink(errorsWriter, cfg.bufferSize, cfg.header(), cfg.unrouted(Emergency, Alert, Critical)...)
+		if err != nil {
+			closeWriters(writers...)
+			return nil, err
+		}
+	}
+	logsSink.stem = cfg.fileStem(logFilename)
+	errorsSink.stem = cfg.fileStem(errorFilename)
+	replicas := openReplicas(cfg, date, logsSink, errorsSink, logFilename, errorFilename)
+	routes, err := newRouteSinks(cfg)
+	if err != nil {
+		closeWriters(writers...)
+		closeSinks(replicas)
+		return nil, err
+	}
+	mirrors, err := openMirrors(cfg)
+	if err != nil {
+		closeWriters(writers...)
+		closeSinks(replicas)
+		return nil, err
+	}
+
+	logger := &Blogger{
+		LogsFile:   logsSink.file,
+		ErrorsFile: errorsSink.file,
+		logs:       logsSink,
+		errors:     errorsSink,
+		replicas:   replicas,
+		routes:     routes,
+		mirrors:    mirrors,
+		dump:       &debugDump{},
+
+		subscribers: &subscribers{},
 
-	logger.Log(
-		Trace,
+		cfg:    cfg,
+		cipher: lineCipher,
+		mu:     cfg.locker(),
+		date:   date,
+		done:   make(chan struct{}),
+		start:  time.Now(),
+	}
+
+	if slices.Contains(cfg.columns(), SequenceColumn) {
+		logger.sequence = &sequencer{}
+	}
+	for _, s := range logger.files() {
+		logger.writeMetadata(s)
+	}
+	if cfg.ringSize > 0 {
+		logger.ring = newRingBuffer[string](cfg.ringSize)
+	}
+	if cfg.replaySize > 0 {
+		logger.replay = newRingBuffer[LogRecord](cfg.replaySize)
+	}
+	if cfg.reservoir != nil {
+		logger.startReservoir(*cfg.reservoir)
+	}
+	if (cfg.bufferSize > 0 || cfg.liveCompression) && cfg.flushInterval > 0 {
+		logger.startFlushTicker(cfg.flushInterval)
+	}
+	if len(cfg.rotationSchedule) > 0 {
+		schedule, _ := parseSchedule(cfg.rotationSchedule)
+		logger.startRotationSchedule(schedule)
+	}
+
+	logger.log(
+		time.Time{},
+		InitSeverity,
 		LogEvent{ProcessType: OsProcess,
 			ProcessId: strconv.Itoa(os.Getpid()),
-			Event:     "Logger initialised successfully"})
+			Event:     InitEvent})
 
-	return &logger, nil
+	return logger, nil
 }
 
 func (b *Blogger) Log(severity Severity, process LogEvent) {
-	msg := fmt.Sprintf("%s,%s,%s,%s,%s",
-		severityName[severity], nowUTC(), processTypeName[process.ProcessType], process.ProcessId, process.Event)
+	b.LogAt(time.Time{}, severity, process)
+}
+
+// LogAt logs the event with ts as its timestamp instead of the current time,
+// e.g. to backfill events from archived data. Such lines are written in the
+// order they are logged, not in timestamp order. A zero ts means now.
+func (b *Blogger) LogAt(ts time.Time, severity Severity, process LogEvent) {
+	b.logAt(ts, severity, process, nil)
+}
+
+// LogWithFields logs the event with fields appended to it for this line
+// only, after the fields of WithFields; a key given to both takes the value
+// given here.
+func (b *Blogger) LogWithFields(severity Severity, process LogEvent, fields map[string]string) {
+	b.logAt(time.Time{}, severity, process, fields)
+}
 
-	switch severity {
-	case Emergency, Alert, Critical:
-		b.errLogger.Println(msg)
-	default:
-		b.stdLogger.Println(msg)
+func (b *Blogger) logAt(ts time.Time, severity Severity, process LogEvent, fields map[string]string) {
+	if b == nil {
+		// the logger failed to build, keep the event rather than crashing
+		process.Event = nilLogger.cfg.appendFields(process.Event, fields)
+		log.Println(nilLogger.format(severity, nilLogger.cfg.timestampAt(ts), process))
+		return
 	}
+	if !b.IsEnabled(severity) {
+		return
+	}
+	process, ok := b.checkEvent(process)
+	if !ok {
+		return
+	}
+	process = b.cfg.transform(process)
+	process.Event = b.cfg.appendFields(process.Event, fields)
+	b.log(ts, severity, b.cfg.capEvent(process))
 }
 
-func (b *Blogger) Close() {
-	if b.ErrorsFile != nil {
-		if err := b.ErrorsFile.Close(); err != nil {
-			// log auto redirect to std err
-			log.Printf("error while closing error logs file: %v\n", err)
+// LogIf logs the event returned by fn, which is only called when severity is
+// enabled, so expensive events cost nothing below WithMinSeverity.
+func (b *Blogger) LogIf(severity Severity, fn func() LogEvent) {
+	if !b.IsEnabled(severity) {
+		return
+	}
+	b.Log(severity, fn())
+}
+
+// log skips validation, used directly for the logger's own events
+func (b *Blogger) log(ts time.Time, severity Severity, process LogEvent) {
+	if !b.IsEnabled(severity) {
+		return
+	}
+	if !severity.AtLeast(b.cfg.minSeverity()) {
+		// only enabled for the debug dump
+		b.write(ts, severity, process)
+		return
+	}
+	b.counts.add(b.cfg.now(), severity)
+
+	if b.cfg.exitsOn(severity) {
+		b.write(ts, severity, process)
+		b.exitAfterEmergency()
+		return
+	}
+	if b.reservoir != nil && b.reservoir.severity == severity {
+		// sampled lines keep the time they were logged at, so they are the
+		// only ones that can appear out of timestamp order in the file
+		b.mu.Lock()
+		line := b.format(severity, b.cfg.timestampAt(ts), process)
+		b.mu.Unlock()
+		b.reservoir.offer(line)
+		return
+	}
+	b.write(ts, severity, process)
+}
+
+func (b *Blogger) format(severity Severity, timestamp string, process LogEvent) string {
+	return b.line(severity, b.fields(severity, timestamp, process))
+}
+
+func (b *Blogger) fields(severity Severity, timestamp string, process LogEvent) []string {
+	return b.columnFields(severity, timestamp, process, b.sequence.number)
+}
+
+// sequence numbers the line, FormatRecord peeks at the number instead of
+// taking it
+func (b *Blogger) columnFields(severity Severity, timestamp string, process LogEvent, sequence func(timestamp string) string) []string {
+	columns := b.cfg.columns()
+	fields := make([]string, 0, len(columns))
+	for _, column := range columns {
+		switch column {
+		case SeverityColumn:
+			fields = append(fields, b.cfg.names.severity(severity))
+		case TimestampColumn:
+			fields = append(fields, timestamp)
+		case ProcessTypeColumn:
+			fields = append(fields, b.cfg.names.processType(process.ProcessType))
+		case ProcessIdColumn:
+			fields = append(fields, process.ProcessId)
+		case EventColumn:
+			fields = append(fields, process.Event)
+		case ServiceColumn:
+			fields = append(fields, b.cfg.serviceName)
+		case SequenceColumn:
+			fields = append(fields, sequence(timestamp))
+		case UptimeColumn:
+			fields = append(fields, time.Since(b.start).String())
 		}
 	}
+	return fields
+}
 
-	if b.LogsFile != nil {
-		if err := b.LogsFile.Close(); err != nil {
-			// log auto redirect to std err
-			log.Printf("error while closing logs file: %v\n", err)
+// FormatRecord returns the bytes Log(severity, event) would write now, after
+// validation, WithTransforms, WithFields and WithMaxEventLength, including
+// the severity prefix, the line ending and every line of an event split by
+// WithMaxLineSize, without writing or counting anything, e.g. to check the
+// output before wiring a custom sink. Lines are returned before encryption.
+// A severity that is not enabled gives no bytes and an event that would be
+// dropped by validation gives its violations, matching ErrInvalidEvent.
+func (b *Blogger) FormatRecord(severity Severity, event LogEvent) ([]byte, error) {
+	if b == nil {
+		return []byte(nilLogger.format(severity, nilLogger.cfg.timestamp(), event) + "\n"), nil
+	}
+	if !severity.AtLeast(b.cfg.minSeverity()) {
+		return nil, nil
+	}
+	event, violations, ok := b.cfg.checkEvent(event)
+	if !ok {
+		return nil, errors.Join(violations...)
+	}
+	event = b.cfg.transform(event)
+	event.Event = b.cfg.appendFields(event.Event, nil)
+	event = b.cfg.capEvent(event)
+
+	b.mu.Lock()
+	lines := b.split(severity, b.columnFields(severity, b.cfg.timestamp(), event, b.sequence.peek))
+	b.mu.Unlock()
+	var out []byte
+	for _, line := range lines {
+		out = append(out, line+b.cfg.lineEnding...)
+	}
+	return out, nil
+}
+
+func (b *Blogger) line(severity Severity, fields []string) string {
+	return b.cfg.severityPrefixes[severity] + csvLine(b.delimiter(), b.cfg.lineEnding, fields...)
+}
+
+// errors are reported once the write mutex is released, so the function
+// given to WithOnError can log again, e.g. to retry the failed event
+func (b *Blogger) write(ts time.Time, severity Severity, process LogEvent) {
+	b.cfg.report(b.writeEvent(ts, severity, process))
+}
+
+// the timestamp is taken under the write mutex so the order of the lines
+// in a file always matches the order of their timestamps
+func (b *Blogger) writeEvent(ts time.Time, severity Severity, process LogEvent) []error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []error
+	if ts.IsZero() {
+		ts, errs = b.guardClock(b.cfg.now())
+	}
+	for _, line := range b.split(severity, b.fields(severity, b.cfg.formatTimestamp(ts), process)) {
+		errs = append(errs, b.writeLine(severity, line, &process)...)
+	}
+	if severity.AtLeast(b.cfg.minSeverity()) {
+		record := LogRecord{Severity: severity, Timestamp: ts, ProcessType: process.ProcessType, ProcessId: process.ProcessId, Event: process.Event}
+		if b.replay != nil {
+			b.replay.add(record)
 		}
+		b.publish(record)
+	}
+	return errs
+}
+
+// writeLine must be called with the write mutex held, it returns the errors
+// to report once the mutex is released. event is reported along with a
+// failed write and may be nil
+func (b *Blogger) writeLine(severity Severity, msg string, event *LogEvent) []error {
+	enabled := severity.AtLeast(b.cfg.minSeverity()) // false when only the debug dump wants it
+	if enabled && b.ring != nil {
+		b.ring.add(msg)
+	}
+
+	if b.cipher != nil {
+		var err error
+		if msg, err = encryptLine(b.cipher, msg); err != nil {
+			// never fall back to writing the line in clear, not even to stderr
+			err = fmt.Errorf("cannot encrypt line: %w", err)
+			return []error{b.cfg.lineError(b.route(severity).path, err, severity, event)}
+		}
+	}
+
+	var errs []error
+	if err := b.writeDump(severity, msg+b.cfg.lineEnding); err != nil {
+		errs = append(errs, err)
+	}
+	if !enabled {
+		return errs
+	}
+
+	if b.cfg.rollover {
+		errs = append(errs, b.rollover()...)
+	}
+
+	// same as log.Logger, a failed write cannot be reported back to the caller,
+	// the line goes to stderr rather than being lost
+	dest := b.route(severity)
+	line := msg + b.cfg.lineEnding
+	written := false
+	for _, s := range append([]*sink{dest}, dest.replicas...) {
+		err := s.write(line)
+		if err == nil && severity.AtLeast(b.cfg.flushThreshold) {
+			err = s.flush()
+		}
+		if err != nil {
+			errs = append(errs, b.cfg.lineError(s.path, err, severity, event))
+			continue
+		}
+		written = true
+	}
+	if written {
+		b.written.add(severity, len(line))
+	} else {
+		fmt.Fprint(os.Stderr, line)
+	}
+
+	for _, m := range b.mirrors {
+		if err := m.writeLine(severity, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (b *Blogger) route(severity Severity) *sink {
+	if dest, ok := b.routes[severity]; ok {
+		return dest
+	}
+	if b.cfg.flatRouting {
+		return b.logs
+	}
+
+	if severity.AtLeast(Critical) {
+		return b.errors
+	}
+	return b.logs
+}
+
+// IsEnabled reports whether events of the given severity are written, to
+// the files or to the debug dump.
+func (b *Blogger) IsEnabled(severity Severity) bool {
+	if b == nil {
+		return true
+	}
+	return severity.AtLeast(b.cfg.minSeverity()) || b.dump.accepts(severity)
+}
+
+// LogContext logs the event unless ctx is already done, in which case the
+// event is dropped and ctx.Err() is returned so cancelled requests can bail out.
+// Events without a ProcessId take the process set by ContextWithProcess.
+func (b *Blogger) LogContext(ctx context.Context, severity Severity, process LogEvent) error {
+	if err := ctx.Err(); err != nil {
+		if b == nil {
+			return err
+		}
+		b.drops.cancelled.Add(1)
+		return err
+	}
+	b.Log(severity, withContextProcess(ctx, process))
+	return nil
+}
+
+// SeverityName returns the name this logger writes for the given severity.
+func (b *Blogger) SeverityName(severity Severity) string {
+	if b == nil {
+		return severity.ToString()
+	}
+	return b.cfg.names.severity(severity)
+}
+
+// ProcessTypeName returns the name this logger writes for the given process type.
+func (b *Blogger) ProcessTypeName(p ProcessType) string {
+	if b == nil {
+		return p.ToString()
+	}
+	return b.cfg.names.processType(p)
+}
+
+// Close stops the background goroutines (flush ticker, reservoir, rotation
+// hooks), writes what they still hold and closes the files. Goroutines that
+// have not stopped within the close timeout are reported as ErrCloseTimeout,
+// the files are closed regardless. Closing the logger again returns
+// ErrLoggerClosed.
+func (b *Blogger) Close() error {
+	if b == nil || b.root != nil {
+		return nil
+	}
+	ctx := context.Background()
+	if b.cfg.closeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.cfg.closeTimeout)
+		defer cancel()
+	}
+	return b.shutdown(ctx, false, func(error) error {
+		return fmt.Errorf("%w after %s", ErrCloseTimeout, b.cfg.closeTimeout)
+	})
+}
+
+// Shutdown is Close bounded by ctx instead of the close timeout, e.g. the
+// grace period of a terminating pod, that also syncs the files before
+// closing them. Once ctx is done it stops waiting for background goroutines
+// and sampled events still pending are abandoned; the files are closed
+// regardless and the error matches both ErrCloseTimeout and ctx.Err().
+func (b *Blogger) Shutdown(ctx context.Context) error {
+	if b == nil || b.root != nil {
+		return nil
+	}
+	return b.shutdown(ctx, true, func(err error) error {
+		return fmt.Errorf("%w: %w", ErrCloseTimeout, err)
+	})
+}
+
+// orchestrated shutdowns also bound the drain by ctx and sync the files,
+// timedOut converts the error of a done ctx
+func (b *Blogger) shutdown(ctx context.Context, orchestrated bool, timedOut func(error) error) error {
+	if !b.closed.CompareAndSwap(false, true) {
+		return ErrLoggerClosed
+	}
+	if orchestrated {
+		b.closing = ctx
+	}
+	close(b.done)
+	var errs []error
+	if err := b.waitBackground(ctx); err != nil {
+		errs = append(errs, timedOut(err))
+	}
+	if abandoned := b.drainAbandoned.Load(); abandoned > 0 {
+		errs = append(errs, fmt.Errorf("%w: %d events", ErrDrainIncomplete, abandoned))
+	}
+
+	if b.cfg.lifecycleLogs {
+		// written directly so the last line is never sampled away
+		b.write(time.Time{}, Trace, LogEvent{
+			ProcessType: OsProcess,
+			ProcessId:   strconv.Itoa(os.Getpid()),
+			Event:       CloseEventPrefix + time.Since(b.start).String()})
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if orchestrated && ctx.Err() == nil {
+		for _, dest := range b.sinks() {
+			errs = append(errs, b.cfg.writeError("sync", dest.path, dest.sync()))
+		}
+	}
+
+	if b.errors != b.logs {
+		if err := b.errors.close(); err != nil {
+			errs = append(errs, fmt.Errorf("error while closing error logs file: %w", err))
+		}
+	}
+
+	for _, replica := range b.replicas {
+		if err := replica.close(); err != nil {
+			errs = append(errs, fmt.Errorf("error while closing mirror file %s: %w", replica.path, err))
+		}
+	}
+
+	for severity, dest := range b.routes {
+		if err := dest.close(); err != nil {
+			errs = append(errs, fmt.Errorf("error while flushing %s route: %w", severity.ToString(), err))
+		}
+	}
+
+	if err := b.logs.close(); err != nil {
+		errs = append(errs, fmt.Errorf("error while closing logs file: %w", err))
+	}
+	if err := b.dump.stop(false); err != nil {
+		errs = append(errs, fmt.Errorf("error while closing debug dump: %w", err))
+	}
+	errs = append(errs, closeMirrors(b.mirrors))
+	b.subscribers.closeAll()
+	return errors.Join(errs...)
+}
+
+func (b *Blogger) waitBackground(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		b.background.Wait()
+		b.hooks.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
 // private functions
-func openOutputFiles(logDirectory string, logFilename string, errorFilename string) (*os.File, *os.File, error) {
-	logsFileTimeExt := strings.Join([]string{todayUTC(), "-", logFilename, ".csv"}, "")
-	errorsFileTimeExt := strings.Join([]string{todayUTC(), "-", errorFilename, ".csv"}, "")
+// resolveErrorFilename returns the name of the errors file; the same name
+// opened twice interleaves partial writes, so an empty or repeated name
+// makes both kinds of lines share a single handle, as with WithSingleFile
+func (c *config) resolveErrorFilename(logFilename string, errorFilename string) string {
+	if errorFilename == "" || errorFilename == logFilename {
+		c.singleFile = true
+		return logFilename
+	}
+	return errorFilename
+}
 
-	// creating directory where only app can write and external user can only read and traverse
-	if err := os.MkdirAll(logDirectory, 0755); err != nil {
-		return nil, nil, err
+// check validates the options that need no destination, returning the
+// cipher when lines are encrypted
+func (c config) check() (cipher.AEAD, error) {
+	if !validDelimiter(c.format.delimiter) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidDelimiter, c.format.delimiter)
+	}
+	if c.compression == Zstd && !zstdAvailable {
+		return nil, ErrZstdUnavailable
+	}
+	if c.singleWriter && (c.reservoir != nil || c.flushInterval > 0 || len(c.rotationSchedule) > 0) {
+		return nil, errors.New("WithSingleWriter cannot be combined with background flushing, scheduled rotation or reservoir sampling")
 	}
+	if _, err := parseSchedule(c.rotationSchedule); err != nil {
+		return nil, err
+	}
+	if c.encryptionKey == nil {
+		return nil, nil
+	}
+	return newLineCipher(c.encryptionKey)
+}
+
+// filename after the date, e.g. app_logs.csv
+func (c config) fileStem(name string) string {
+	if c.liveCompression {
+		return name + ".csv.gz"
+	}
+	return name + ".csv"
+}
+
+// path of the file for the given date (see fileDate)
+func (c config) datedPath(dir string, date string, stem string) string {
+	return filepath.Join(dir, strings.Join([]string{date, "-", stem}, ""))
+}
+
+// ExpectedFilename returns the path of the file NewLogger(dir, name, ...)
+// writes to at t when given opts, following its zone (WithFilenameTimeZone
+// or WithLocalTime), rotation interval (WithRotationInterval) and extension
+// (WithLiveCompression), so tools can find the files without rebuilding
+// the names themselves.
+func ExpectedFilename(dir string, name string, t time.Time, opts ...Option) string {
+	cfg := newConfig(opts)
+	return cfg.datedPath(dir, cfg.fileDateAt(t), cfg.fileStem(name))
+}
 
-	// create files, only app the write and read, all the others can read only
-	logsFilepath := filepath.Join(logDirectory, logsFileTimeExt)
-	logFile, err := os.OpenFile(logsFilepath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+// dated paths of the files for date
+func outputPaths(cfg config, date string, logDirectory string, logFilename string, errorFilename string) (string, string) {
+	return cfg.datedPath(logDirectory, date, cfg.fileStem(logFilename)), cfg.datedPath(logDirectory, date, cfg.fileStem(errorFilename))
+}
+
+func openOutputFiles(cfg config, date string, logDirectory string, logFilename string, errorFilename string) (*namedWriter, *namedWriter, error) {
+	logsFilepath, errorsFilepath := outputPaths(cfg, date, logDirectory, logFilename, errorFilename)
+
+	// creating directory where only app can write and external user can only read and traverse,
+	// custom writers are responsible for their own destination
+	if cfg.writerFactory == nil {
+		if err := os.MkdirAll(logDirectory, 0755); err != nil {
+			return nil, nil, fmt.Errorf("%w %s: %w", ErrLogDirCreate, logDirectory, err)
+		}
+	}
+
+	logWriter, err := cfg.openWriter(logsFilepath)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("%w %s: %w", ErrLogFileOpen, logsFilepath, err)
 	}
 
-	errorsFilepath := filepath.Join(logDirectory, errorsFileTimeExt)
-	errorFile, err := os.OpenFile(errorsFilepath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if cfg.oneFile() {
+		return logWriter, logWriter, nil
+	}
+
+	errorWriter, err := cfg.openWriter(errorsFilepath)
 	if err != nil {
-		if err := logFile.Close(); err != nil {
-			return nil, nil, err
+		err = fmt.Errorf("%w %s: %w", ErrLogFileOpen, errorsFilepath, err)
+		if closeErr := logWriter.Close(); closeErr != nil {
+			return nil, nil, errors.Join(err, closeErr)
 		}
 		return nil, nil, err
 	}
-	return logFile, errorFile, nil
+	return logWriter, errorWriter, nil
+}
+
+// only app the write and read, all the others can read only
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
 }
 
-func todayUTC() string {
-	return time.Now().UTC().Format("2006-01-02")
+func closeWriters(writers ...*namedWriter) {
+	for _, w := range writers {
+		if err := w.Close(); err != nil {
+			// log auto redirect to std err
+			log.Printf("error while closing %s: %v\n", w.name, err)
+		}
+	}
+}
+
+// lines of a nil logger keep the default comma
+func (b *Blogger) delimiter() rune {
+	if b.cfg.format == nil {
+		return ','
+	}
+	return b.cfg.format.delimiter
+}
+
+// fields are quoted the same way encoding/csv does, so events containing
+// the delimiter, quotes, line breaks or the record separator can be told
+// apart from extra columns and records
+func csvLine(delimiter rune, separator string, fields ...string) string {
+	var line strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			line.WriteRune(delimiter)
+		}
+		special := strings.ContainsAny(field, string(delimiter)+"\"\r\n") || separator != "" && strings.Contains(field, separator)
+		if field == "" || !special && field[0] != ' ' && field[0] != '\t' {
+			line.WriteString(field)
+			continue
+		}
+		line.WriteByte('"')
+		line.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		line.WriteByte('"')
+	}
+	return line.String()
+}
+
+// same rules as encoding/csv, anything else could not be read back
+func validDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+func (c config) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
+
+// date part of the filenames, in the configured zone (UTC by default),
+// down to the hour with hourly rotation
+func (c config) fileDate() string {
+	return c.fileDateAt(c.now())
+}
+
+func (c config) fileDateAt(t time.Time) string {
+	return t.In(c.fileLocation()).Format(c.rotationInterval.layout())
+}
+
+func (c config) fileLocation() *time.Location {
+	if c.filenameLocation != nil {
+		return c.filenameLocation
+	}
+	return c.location()
+}
+
+// zone of the line timestamps
+func (c config) location() *time.Location {
+	if c.localTime {
+		return time.Local
+	}
+	return time.UTC
+}
+
+func (c config) timestamp() string {
+	return c.formatTimestamp(c.now())
+}
+
+// the given time, or now when it is zero
+func (c config) timestampAt(ts time.Time) string {
+	if ts.IsZero() {
+		return c.timestamp()
+	}
+	return c.formatTimestamp(ts)
 }
 
-func nowUTC() string {
-	return time.Now().UTC().Format(time.RFC3339)
+func (c config) formatTimestamp(t time.Time) string {
+	return t.In(c.location()).Format(time.RFC3339)
 }
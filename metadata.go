@@ -0,0 +1,98 @@
+package goutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// appended to the path of a log file to name its sidecar
+const metadataSuffix = ".meta.json"
+
+// Metadata describes how a log file is written, see WithSidecarMetadata.
+type Metadata struct {
+	Format        string    `json:"format"` // always "csv", with Delimiter between fields
+	SchemaVersion int       `json:"schema_version"`
+	Columns       []string  `json:"columns"`
+	Delimiter     string    `json:"delimiter"`
+	LineEnding    string    `json:"line_ending"`
+	Severities    []string  `json:"severities"` // written to this file
+	Encrypted     bool      `json:"encrypted"`
+	Compressed    bool      `json:"compressed"` // a gzip stream, with WithLiveCompression
+	Host          string    `json:"host"`
+	PID           int       `json:"pid"`
+	Started       time.Time `json:"started"` // when the logger was created
+	Opened        time.Time `json:"opened"`  // when the file was created or last rotated
+}
+
+// WithSidecarMetadata writes a JSON file describing the layout of every log
+// file next to it, named after the file with ".meta.json" appended, so a log
+// viewer can configure parsing without being told the options. It is
+// rewritten whenever the file is opened again by a rotation. Writers from
+// WithWriterFactory get no sidecar. Failures are reported through OnError.
+func WithSidecarMetadata() Option {
+	return func(c *config) {
+		c.sidecarMetadata = true
+	}
+}
+
+// ReadMetadata returns the sidecar metadata of the log file at path.
+func ReadMetadata(path string) (Metadata, error) {
+	content, err := os.ReadFile(path + metadataSuffix)
+	if err != nil {
+		return Metadata{}, err
+	}
+	var metadata Metadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return Metadata{}, fmt.Errorf("%s: %w", path+metadataSuffix, err)
+	}
+	return metadata, nil
+}
+
+// writeMetadata must be called with the write mutex held or before the
+// logger is returned, the sidecar is replaced in one rename so readers never
+// see it half written
+func (b *Blogger) writeMetadata(s *sink) {
+	if !b.cfg.sidecarMetadata || s.file == nil {
+		return
+	}
+
+	columns := make([]string, 0, len(b.cfg.columns()))
+	for _, column := range b.cfg.columns() {
+		columns = append(columns, column.ToString())
+	}
+	severities := make([]string, 0, len(s.severities))
+	for _, severity := range s.severities {
+		severities = append(severities, severity.ToString())
+	}
+	host, _ := os.Hostname()
+	content, err := json.MarshalIndent(Metadata{
+		Format:        "csv",
+		SchemaVersion: schemaVersion,
+		Columns:       columns,
+		Delimiter:     string(b.delimiter()),
+		LineEnding:    b.cfg.lineEnding,
+		Severities:    severities,
+		Encrypted:     b.cipher != nil,
+		Compressed:    b.cfg.liveCompression,
+		Host:          host,
+		PID:           os.Getpid(),
+		Started:       b.start,
+		Opened:        b.cfg.now(),
+	}, "", "  ")
+	if err != nil {
+		b.cfg.onError(err)
+		return
+	}
+
+	path := s.path + metadataSuffix
+	if err := os.WriteFile(path+".tmp", content, 0644); err != nil {
+		b.cfg.onError(fmt.Errorf("error while writing %s: %w", path, err))
+		return
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		os.Remove(path + ".tmp")
+		b.cfg.onError(fmt.Errorf("error while writing %s: %w", path, err))
+	}
+}
@@ -0,0 +1,56 @@
+package goutils
+
+import "fmt"
+
+// destination receiving every line in addition to the files, e.g. the
+// Windows Event Log
+type mirror interface {
+	writeLine(severity Severity, line string) error
+	Close() error
+}
+
+func openMirrors(c config) ([]mirror, error) {
+	var mirrors []mirror
+	for _, open := range c.mirrors {
+		m, err := open()
+		if err != nil {
+			closeMirrors(mirrors)
+			return nil, err
+		}
+		mirrors = append(mirrors, m)
+	}
+	return mirrors, nil
+}
+
+func closeMirrors(mirrors []mirror) error {
+	var first error
+	for _, m := range mirrors {
+		if err := m.Close(); err != nil && first == nil {
+			first = fmt.Errorf("error while closing mirror: %w", err)
+		}
+	}
+	return first
+}
+
+// WithWriteFunc also hands every line to write, formatted (and encrypted
+// with WithEncryption) but without the line ending, alongside the files,
+// e.g. to batch lines into a remote API. Errors it returns are reported
+// through OnError. write is called with the write mutex held, in the order
+// the lines are written, so hand slow work off to another goroutine. To
+// deliver lines only through write, combine it with a WithWriterFactory
+// returning a writer that discards them.
+func WithWriteFunc(write func(severity Severity, line []byte) error) Option {
+	return func(c *config) {
+		c.mirrors = append(c.mirrors, func() (mirror, error) {
+			return writeFuncMirror(write), nil
+		})
+	}
+}
+
+type writeFuncMirror func(severity Severity, line []byte) error
+
+func (w writeFuncMirror) writeLine(severity Severity, line string) error {
+	return w(severity, []byte(line))
+}
+
+func (writeFuncMirror) Close() error { return nil }
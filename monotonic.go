@@ -0,0 +1,57 @@
+package goutils
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// WithMonotonicGuard watches for the clock going backwards between lines
+// (e.g. an NTP step correction) and logs a Notice event when it does, so
+// downstream tools are warned their ordering assumptions broke. Lines
+// logged with LogAt or sampled by a reservoir are not checked.
+func WithMonotonicGuard() Option {
+	return func(c *config) {
+		c.monotonicGuard = true
+	}
+}
+
+// WithMonotonicClamp is WithMonotonicGuard that also writes lines logged
+// after a regression with the last timestamp plus one nanosecond, keeping
+// the files in timestamp order until the clock catches up.
+func WithMonotonicClamp() Option {
+	return func(c *config) {
+		c.monotonicGuard = true
+		c.monotonicClamp = true
+	}
+}
+
+// guardClock must be called with the write mutex held, it returns the time
+// to write the line with and the errors of the warning line
+func (b *Blogger) guardClock(now time.Time) (time.Time, []error) {
+	if !b.cfg.monotonicGuard {
+		return now, nil
+	}
+
+	last := b.lastStamp
+	if last.IsZero() || !now.Before(last) {
+		b.lastStamp = now
+		return now, nil
+	}
+
+	var errs []error
+	if b.IsEnabled(Notice) {
+		errs = b.writeLine(Notice, b.format(Notice, b.cfg.formatTimestamp(now), LogEvent{
+			ProcessType: OsProcess,
+			ProcessId:   strconv.Itoa(os.Getpid()),
+			Event:       fmt.Sprintf("Clock went backwards by %s, from %s to %s", last.Sub(now), last.UTC().Format(time.RFC3339Nano), now.UTC().Format(time.RFC3339Nano)),
+		}), nil)
+	}
+	if !b.cfg.monotonicClamp {
+		b.lastStamp = now
+		return now, errs
+	}
+	b.lastStamp = last.Add(time.Nanosecond)
+	return b.lastStamp, errs
+}
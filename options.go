@@ -0,0 +1,355 @@
+package goutils
+
+import (
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Option customises a Blogger at construction time.
+type Option func(*config)
+
+type config struct {
+	names names
+
+	level       *atomic.Int32 // least important severity still written
+	sharedLevel bool          // level comes from WithSharedLevel
+
+	eventRules     *EventRules // nil accepts every event
+	maxEventLength int         // in runes, 0 keeps events whole
+	maxLineSize    int         // in bytes, 0 never splits events
+	emptyEvent     EmptyEventPolicy
+
+	serviceName string            // extra column after the event, omitted when empty
+	fields      map[string]string // appended to every event
+	transforms  []func(*LogEvent) // run in order on every event
+
+	severityPrefixes map[Severity]string // written before the fields
+
+	format *lineFormat // columns and delimiter, see SetLineFormat
+
+	lineEnding   string
+	utf8BOM      bool // byte-order mark opens every file
+	schemaHeader bool // comment line declaring the columns opens every file
+	sequence     bool // per-second counter column after the others
+	uptime       bool // time since NewLogger column after the others
+
+	clock            func() time.Time // nil uses time.Now
+	monotonicGuard   bool             // warn when the clock goes backwards
+	monotonicClamp   bool             // and keep timestamps increasing
+	localTime        bool             // timestamps and dates in time.Local
+	filenameLocation *time.Location   // zone of the date in filenames, overrides localTime
+	rotationInterval RotationInterval // resolution of the date in filenames
+	rollover         bool             // new files when the date changes
+	rotationSchedule []string         // HH:MM times of the day to rotate at
+	compression      CompressionAlgo
+	liveCompression  bool // gzip the active files
+
+	bufferSize     int      // 0 writes straight to the files
+	flushThreshold Severity // buffered events at or above it are flushed at once
+	flushInterval  time.Duration
+
+	encryptionKey []byte // nil when lines are written in clear
+
+	reservoir    *reservoirConfig // nil disables reservoir sampling
+	drainTimeout time.Duration    // 0 writes the whole sample on Close
+	ringSize     int              // 0 keeps no recent lines in memory
+	replaySize   int              // 0 keeps no recent events in memory
+
+	routes  map[Severity]io.Writer
+	mirrors []func() (mirror, error) // opened by NewLogger
+
+	mirrorDirectories []string // second copies of the files
+	sidecarMetadata   bool     // JSON file describing each log file
+
+	writerFactory func(name string) (io.WriteCloser, error) // nil opens local files
+	singleFile    bool                                      // errors share the standard sink
+	flatRouting   bool                                      // every severity routed to the standard sink
+	exclusiveLock bool                                      // advisory lock on the local files
+	writeAttempts int                                       // 0 or 1 never retries
+	writeBackoff  time.Duration
+
+	lifecycleLogs bool
+	closeTimeout  time.Duration // 0 waits for background goroutines forever
+
+	mutex        *sync.Mutex
+	singleWriter bool // no locking at all
+
+	rotationHook func(oldPath, newPath string)
+	onError      func(error)
+
+	errorSeverities map[error]Severity // overrides in WriteError
+
+	idGenerator func() string // request ids when none is supplied
+
+	exitOnEmergency bool
+	exitCode        int
+	exit            func(code int) // os.Exit unless replaced
+
+	swallowPanics bool // Recover does not panic again
+
+	requestSeverity      Severity // successful requests
+	requestErrorSeverity Severity // failed requests
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		onError:              logToStderr,
+		mutex:                new(sync.Mutex),
+		level:                newLevel(Trace),
+		lineEnding:           "\n",
+		format:               &lineFormat{delimiter: ','},
+		closeTimeout:         5 * time.Second,
+		flushThreshold:       Critical,
+		idGenerator:          randomID,
+		exit:                 os.Exit,
+		requestSeverity:      Notice,
+		requestErrorSeverity: Critical,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.liveCompression && cfg.flushInterval == 0 {
+		cfg.flushInterval = liveFlushInterval
+	}
+	return cfg
+}
+
+// WithSeverityNames overrides the names written for the given severities
+// (e.g. "FATAL" instead of "EMERGENCY") for this logger only. Routing is
+// still decided by the severity itself, not by its name.
+func WithSeverityNames(overrides map[Severity]string) Option {
+	return func(c *config) {
+		if c.names.severities == nil {
+			c.names.severities = make(map[Severity]string, len(overrides))
+		}
+		for severity, name := range overrides {
+			c.names.severities[severity] = name
+		}
+	}
+}
+
+// WithSeverityAlias writes the given severities under the name of another
+// one for ingestion systems that only know the standard names, e.g. Trace as
+// "DEBUG". Routing and filtering still use the real severity. Aliases take
+// precedence over WithSeverityNames; read such files back with
+// ParseWithSeverityAlias.
+func WithSeverityAlias(aliases map[Severity]string) Option {
+	return func(c *config) {
+		if c.names.aliases == nil {
+			c.names.aliases = make(map[Severity]string, len(aliases))
+		}
+		for severity, alias := range aliases {
+			c.names.aliases[severity] = alias
+		}
+	}
+}
+
+// WithProcessTypeNames overrides the names written for the given process
+// types for this logger only.
+func WithProcessTypeNames(overrides map[ProcessType]string) Option {
+	return func(c *config) {
+		if c.names.processTypes == nil {
+			c.names.processTypes = make(map[ProcessType]string, len(overrides))
+		}
+		for p, name := range overrides {
+			c.names.processTypes[p] = name
+		}
+	}
+}
+
+// WithMinSeverity discards events less important than severity, e.g. with
+// Notice only Emergency, Alert, Critical and Notice events are written.
+func WithMinSeverity(severity Severity) Option {
+	return func(c *config) {
+		c.level = newLevel(severity)
+		c.sharedLevel = false
+	}
+}
+
+// WithServiceName appends a constant column with the emitting service name
+// to every line, after the event.
+func WithServiceName(name string) Option {
+	return func(c *config) {
+		c.serviceName = name
+	}
+}
+
+// WithSeverityPrefix writes a leading token such as "[ERR] " before the
+// fields of lines with the given severities, for tooling keying off it.
+// Prefixes are written as given, include a trailing space if wanted; they
+// are not part of the CSV record so strip them before ParseReader.
+func WithSeverityPrefix(prefixes map[Severity]string) Option {
+	return func(c *config) {
+		if c.severityPrefixes == nil {
+			c.severityPrefixes = make(map[Severity]string, len(prefixes))
+		}
+		for severity, prefix := range prefixes {
+			c.severityPrefixes[severity] = prefix
+		}
+	}
+}
+
+// WithLineEnding sets the terminator written after every line, e.g. "\r\n"
+// for tooling expecting Windows line endings. Defaults to "\n".
+func WithLineEnding(ending string) Option {
+	return func(c *config) {
+		if ending != "" {
+			c.lineEnding = ending
+		}
+	}
+}
+
+// WithRecordSeparator ends every record with sep instead of a line break,
+// e.g. a NUL byte for sinks that do not split on "\n". An empty separator
+// writes records back to back, for writers from WithWriterFactory doing
+// their own (e.g. length-prefix) framing of each unbuffered write. Read such
+// files back with ParseWithRecordSeparator. Replaces WithLineEnding.
+func WithRecordSeparator(sep []byte) Option {
+	return func(c *config) {
+		c.lineEnding = string(sep)
+	}
+}
+
+// WithDelimiter separates fields with the given rune instead of a comma,
+// e.g. '\t' for TSV or '|' for pipe-delimited output. Fields containing it
+// are quoted; read such files back with ParseWithDelimiter. NewLogger returns
+// ErrInvalidDelimiter for a quote, a line break or an invalid rune.
+func WithDelimiter(delimiter rune) Option {
+	return func(c *config) {
+		c.format.delimiter = delimiter
+	}
+}
+
+// WithClock replaces time.Now as the source of line timestamps, filename
+// dates and backup stamps, e.g. to pin the time in tests. The uptime is
+// still measured with the real clock.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.clock = now
+	}
+}
+
+// WithFilenameTimeZone dates the files in loc instead of UTC, so a team in
+// one timezone finds today's lines in the file named after its own today.
+// Line timestamps stay in UTC unless WithLocalTime is given.
+func WithFilenameTimeZone(loc *time.Location) Option {
+	return func(c *config) {
+		if loc != nil {
+			c.filenameLocation = loc
+		}
+	}
+}
+
+// WithLocalTime writes line timestamps with the offset of time.Local and
+// dates the files in time.Local, instead of UTC, for the common case of a
+// logger read by people in a single timezone. WithFilenameTimeZone still
+// decides the zone of the filenames, whatever the order of the options.
+func WithLocalTime() Option {
+	return func(c *config) {
+		c.localTime = true
+	}
+}
+
+// WithRequestSeverities sets the severities used by the request interceptors
+// for successful and failed requests (Notice and Critical by default).
+func WithRequestSeverities(success Severity, failure Severity) Option {
+	return func(c *config) {
+		c.requestSeverity = success
+		c.requestErrorSeverity = failure
+	}
+}
+
+// WithRotationHook registers a function called after each rotation with the
+// final path of the rotated file (including the compression extension) and
+// the path of the fresh active file. It runs asynchronously, a panic inside
+// the hook is recovered and reported through OnError.
+func WithRotationHook(hook func(oldPath, newPath string)) Option {
+	return func(c *config) {
+		c.rotationHook = hook
+	}
+}
+
+// WithOnError sets the function receiving errors the logger cannot return to
+// the caller, by default they are written to stderr. Failed writes to the
+// files are reported as a *WriteError, retrieve it with errors.As.
+func WithOnError(onError func(error)) Option {
+	return func(c *config) {
+		if onError != nil {
+			c.onError = onError
+		}
+	}
+}
+
+// report passes errors collected under the write mutex to onError
+func (c config) report(errs []error) {
+	for _, err := range errs {
+		c.onError(err)
+	}
+}
+
+func logToStderr(err error) {
+	// log auto redirect to std err
+	log.Printf("logger error: %v\n", err)
+}
+
+// WithLifecycleLogs logs a shutdown event with the logger uptime on Close,
+// mirroring the initialisation event logged at startup.
+func WithLifecycleLogs() Option {
+	return func(c *config) {
+		c.lifecycleLogs = true
+	}
+}
+
+// WithCloseTimeout bounds how long Close waits for background goroutines
+// such as rotation hooks to stop, 5 seconds by default. Zero waits forever.
+func WithCloseTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.closeTimeout = d
+	}
+}
+
+// WithMutex makes the logger guard its writes with mu instead of a private
+// mutex. Loggers pointed at the same destination (the same path or a writer
+// shared through WithWriterFactory) should all be given the same mutex so
+// that each line is written atomically:
+//
+//	var mu sync.Mutex
+//	requests, _ := NewLogger(dir, "app", "errors", WithMutex(&mu))
+//	background, _ := NewLogger(dir, "app", "errors", WithMutex(&mu))
+func WithMutex(mu *sync.Mutex) Option {
+	return func(c *config) {
+		if mu != nil {
+			c.mutex = mu
+		}
+	}
+}
+
+// WithSingleWriter drops the write mutex for programs logging from a single
+// goroutine, such as CLI tools, saving the locking on every line. The logger
+// is then unsafe for concurrent use, including Flush, Rotate and Close from
+// another goroutine, which the race detector reports; it cannot be combined
+// with WithFlushInterval, WithLiveCompression, WithRotationSchedule or
+// WithReservoir since they write from background goroutines. Replaces
+// WithMutex.
+func WithSingleWriter() Option {
+	return func(c *config) {
+		c.singleWriter = true
+	}
+}
+
+func (c config) locker() sync.Locker {
+	if c.singleWriter {
+		return noLock{}
+	}
+	return c.mutex
+}
+
+// sync.Locker of a logger used by a single goroutine
+type noLock struct{}
+
+func (noLock) Lock()   {}
+func (noLock) Unlock() {}
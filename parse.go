@@ -0,0 +1,388 @@
+package goutils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// LogRecord is a single parsed log line.
+type LogRecord struct {
+	Severity    Severity
+	Timestamp   time.Time
+	ProcessType ProcessType
+	ProcessId   string
+	Event       string
+
+	// columns past the core five, keyed by their header name or by their
+	// 1-based position (e.g. "column6") when no header row was found
+	Extra map[string]string
+}
+
+// CountInitLines returns how many of records are the event NewLogger writes
+// on startup, one per logger that opened the file (or per restart).
+func CountInitLines(records []LogRecord) int {
+	count := 0
+	for _, record := range records {
+		if record.Severity == InitSeverity && record.ProcessType == OsProcess && record.Event == InitEvent {
+			count++
+		}
+	}
+	return count
+}
+
+// names of the core columns as they appear in a header row
+var coreColumns = []string{"severity", "timestamp", "process_type", "process_id", "event"}
+
+// ParseSeverity returns the severity with the given default name, ignoring case.
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityName {
+		if strings.EqualFold(severityName, name) {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// ParseProcessType returns the process type with the given default name, ignoring case.
+func ParseProcessType(name string) (ProcessType, error) {
+	for p, processName := range processTypeName {
+		if strings.EqualFold(processName, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown process type %q", name)
+}
+
+// ParseOption customises how ParseLogFile and ParseReader read a file, it
+// should mirror the options the file was written with.
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	delimiter  rune
+	separator  []byte              // nil splits lines on "\n" or "\r\n"
+	header     []string            // column names until a header row, nil for the default layout
+	severities map[string]Severity // upper-cased names, nil reads the default names
+}
+
+// ParseWithDelimiter reads files written with WithDelimiter.
+func ParseWithDelimiter(delimiter rune) ParseOption {
+	return func(c *parseConfig) {
+		c.delimiter = delimiter
+	}
+}
+
+// ParseWithRecordSeparator reads files written with WithRecordSeparator,
+// separators inside quoted fields do not end a record.
+func ParseWithRecordSeparator(separator []byte) ParseOption {
+	return func(c *parseConfig) {
+		c.separator = separator
+	}
+}
+
+// ParseWithSeverityAlias reads files written with WithSeverityAlias. A name
+// written for several severities, such as "DEBUG" when Trace is aliased to
+// it, reads back as the most severe of them.
+func ParseWithSeverityAlias(aliases map[Severity]string) ParseOption {
+	return func(c *parseConfig) {
+		written := names{aliases: aliases}
+		c.severities = make(map[string]Severity, len(severityName))
+		for severity := range severityName {
+			name := strings.ToUpper(written.severity(severity))
+			if current, ok := c.severities[name]; !ok || severity.MoreSevereThan(current) {
+				c.severities[name] = severity
+			}
+		}
+	}
+}
+
+// ParseLogFile parses every line of the log file at path.
+func ParseLogFile(path string, opts ...ParseOption) ([]LogRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseReader(file, opts...)
+}
+
+// ParseDelimited is ParseReader for files written with WithDelimiter.
+func ParseDelimited(r io.Reader, delimiter rune) ([]LogRecord, error) {
+	return ParseReader(r, ParseWithDelimiter(delimiter))
+}
+
+// ParseReader parses log lines written with the default names. Lines may
+// carry any number of columns after the core five, so files mixing legacy
+// and extended layouts can be read. A header row (first column "severity")
+// names the columns of the lines that follow it, as does a schema header
+// written with WithSchemaHeader; other "#" comment lines are skipped. Extra
+// columns are stored in LogRecord.Extra. Both "\n" and "\r\n" line endings
+// are accepted and gzip or zstd compressed input, such as rotated backups,
+// is detected and decompressed.
+func ParseReader(r io.Reader, opts ...ParseOption) ([]LogRecord, error) {
+	reader, err := newRecordReader(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []LogRecord
+	for {
+		record, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+// ParseLine parses a single record, e.g. a line received from a socket,
+// with or without its line ending, through the same reader as ParseReader
+// so quoted fields and extra columns are handled alike. A single line has
+// no header row, name extra columns with ParseWithColumns. Header rows,
+// comments and input holding several records are errors.
+func ParseLine(line string, opts ...ParseOption) (LogRecord, error) {
+	reader, err := newRecordReader(strings.NewReader(line), opts)
+	if err != nil {
+		return LogRecord{}, err
+	}
+	record, err := reader.next()
+	if errors.Is(err, io.EOF) {
+		return LogRecord{}, fmt.Errorf("no record in %q", line)
+	}
+	if err != nil {
+		return LogRecord{}, err
+	}
+	if _, err := reader.next(); !errors.Is(err, io.EOF) {
+		return LogRecord{}, fmt.Errorf("more than one record in %q", line)
+	}
+	return record, nil
+}
+
+// parses one record at a time, keeping track of the last header
+type recordReader struct {
+	fields     fieldReader
+	delimiter  rune
+	header     []string
+	severities map[string]Severity
+}
+
+func newRecordReader(r io.Reader, opts []ParseOption) (*recordReader, error) {
+	cfg := parseConfig{delimiter: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !validDelimiter(cfg.delimiter) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidDelimiter, cfg.delimiter)
+	}
+
+	if cfg.separator != nil && len(cfg.separator) == 0 {
+		return nil, errors.New("empty record separator, records cannot be told apart")
+	}
+	r, err := decompressed(r)
+	if err != nil {
+		return nil, err
+	}
+	r = skipBOM(r)
+
+	var fields fieldReader
+	switch string(cfg.separator) {
+	case "", "\n", "\r\n":
+		fields = newCSVReader(r, cfg.delimiter)
+	default:
+		fields = newSeparatedReader(r, cfg.delimiter, cfg.separator)
+	}
+	return &recordReader{fields: fields, delimiter: cfg.delimiter, header: cfg.header, severities: cfg.severities}, nil
+}
+
+// next returns io.EOF once every record has been read
+func (rr *recordReader) next() (LogRecord, error) {
+	for {
+		fields, line, err := rr.fields.read()
+		if err != nil {
+			return LogRecord{}, err
+		}
+
+		if strings.EqualFold(fields[0], coreColumns[0]) {
+			rr.header = fields
+			continue
+		}
+		if strings.HasPrefix(fields[0], "#") {
+			// the fields of a comment are split at the delimiter, rejoin them
+			if _, columns, ok := ParseSchemaHeader(strings.Join(fields, string(rr.delimiter))); ok {
+				rr.header = columns
+			}
+			continue
+		}
+
+		record, err := parseFields(fields, rr.header, rr.severities)
+		if err != nil {
+			return LogRecord{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		return record, nil
+	}
+}
+
+// returns the fields of the next record and the line (or record number) it
+// starts at
+type fieldReader interface {
+	read() ([]string, int, error)
+}
+
+type csvReader struct {
+	reader *csv.Reader
+}
+
+func newCSVReader(r io.Reader, delimiter rune) *csvReader {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	return &csvReader{reader: reader}
+}
+
+func (c *csvReader) read() ([]string, int, error) {
+	fields, err := c.reader.Read()
+	if err != nil {
+		return nil, 0, err
+	}
+	line, _ := c.reader.FieldPos(0)
+	return fields, line, nil
+}
+
+// records ending with a custom separator, each one is parsed on its own
+type separatedReader struct {
+	scanner   *bufio.Scanner
+	delimiter rune
+	record    int
+}
+
+func newSeparatedReader(r io.Reader, delimiter rune, separator []byte) *separatedReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	scanner.Split(splitRecords(separator))
+	return &separatedReader{scanner: scanner, delimiter: delimiter}
+}
+
+func (s *separatedReader) read() ([]string, int, error) {
+	for s.scanner.Scan() {
+		s.record++
+		if len(s.scanner.Bytes()) == 0 {
+			continue
+		}
+		reader := csv.NewReader(bytes.NewReader(s.scanner.Bytes()))
+		reader.Comma = s.delimiter
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		fields, err := reader.Read()
+		if err != nil {
+			return nil, s.record, fmt.Errorf("record %d: %w", s.record, err)
+		}
+		return fields, s.record, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, s.record, err
+	}
+	return nil, s.record, io.EOF
+}
+
+// bufio.SplitFunc cutting records at separator, outside quoted fields
+func splitRecords(separator []byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		quoted := false
+		for i := 0; i < len(data); i++ {
+			if data[i] == '"' {
+				quoted = !quoted
+				continue
+			}
+			if !quoted && bytes.HasPrefix(data[i:], separator) {
+				return i + len(separator), data[:i], nil
+			}
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
+
+// with a header core columns it does not name are left zero and fields
+// past it are extras, without one the first five fields are the core ones
+func parseFields(fields []string, header []string, severities map[string]Severity) (LogRecord, error) {
+	if len(fields) < len(coreColumns) && len(fields) > len(header) {
+		return LogRecord{}, fmt.Errorf("expected at least %d fields, got %d", len(coreColumns), len(fields))
+	}
+
+	// map every field to its column name, defaulting to the core layout
+	values := make(map[string]string, len(fields))
+	var extra map[string]string
+	for i, field := range fields {
+		name := ""
+		switch {
+		case i < len(header):
+			name = strings.ToLower(header[i])
+		case header == nil && i < len(coreColumns):
+			name = coreColumns[i]
+		default:
+			name = fmt.Sprintf("column%d", i+1)
+		}
+
+		if isCoreColumn(name) {
+			values[name] = field
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]string)
+		}
+		extra[name] = field
+	}
+
+	var record LogRecord
+	var err error
+	if value, ok := values["severity"]; ok {
+		if record.Severity, err = parseSeverityName(value, severities); err != nil {
+			return LogRecord{}, err
+		}
+	}
+	if value, ok := values["timestamp"]; ok {
+		if record.Timestamp, err = time.Parse(time.RFC3339, value); err != nil {
+			return LogRecord{}, err
+		}
+	}
+	if value, ok := values["process_type"]; ok {
+		if record.ProcessType, err = ParseProcessType(value); err != nil {
+			return LogRecord{}, err
+		}
+	}
+	record.ProcessId = values["process_id"]
+	record.Event = values["event"]
+	record.Extra = extra
+	return record, nil
+}
+
+func parseSeverityName(name string, severities map[string]Severity) (Severity, error) {
+	if severities == nil {
+		return ParseSeverity(name)
+	}
+	if severity, ok := severities[strings.ToUpper(name)]; ok {
+		return severity, nil
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+func isCoreColumn(name string) bool {
+	for _, column := range coreColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package goutils
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// WithSwallowPanics makes the function returned by Recover stop a recovered
+// panic once it is logged instead of panicking again with the same value.
+func WithSwallowPanics() Option {
+	return func(c *config) {
+		c.swallowPanics = true
+	}
+}
+
+// Recover returns a function to be deferred at the top of a goroutine:
+//
+//	go func() {
+//		defer logger.Recover(goutils.OsProcess, "worker-1")()
+//		...
+//	}()
+//
+// If the goroutine panics the recovered value and the stack trace are logged
+// as a Critical event and synced to disk, then the panic is resumed unless
+// the logger was created with WithSwallowPanics.
+func (b *Blogger) Recover(processType ProcessType, processId string) func() {
+	return func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		b.Log(Critical, LogEvent{
+			ProcessType: processType,
+			ProcessId:   processId,
+			Event:       fmt.Sprintf("panic: %v\n%s", r, debug.Stack())})
+		if err := b.Sync(); err != nil {
+			b.cfg.onError(fmt.Errorf("error while syncing after panic: %w", err))
+		}
+		if b == nil || !b.cfg.swallowPanics {
+			panic(r)
+		}
+	}
+}
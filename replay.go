@@ -0,0 +1,59 @@
+package goutils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReplayJSONL logs every JSON object read from r, one per line with the
+// fields written by ExportJSONArray, into target with LogAt so the original
+// timestamps are kept and routing, rotation and formatting apply as for any
+// other event. Objects without a timestamp are logged now and extra fields
+// are dropped. Malformed lines are reported through OnError and skipped, the
+// returned error is about reading r.
+func ReplayJSONL(r io.Reader, target *Blogger) error {
+	if target == nil {
+		return errors.New("cannot replay into a nil logger")
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		ts, severity, event, err := replayedEvent(line)
+		if err != nil {
+			target.cfg.onError(fmt.Errorf("line %d: %w", lineNumber, err))
+			continue
+		}
+		target.LogAt(ts, severity, event)
+	}
+	return scanner.Err()
+}
+
+func replayedEvent(line []byte) (time.Time, Severity, LogEvent, error) {
+	var record jsonRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return time.Time{}, 0, LogEvent{}, err
+	}
+	severity, err := ParseSeverity(record.Severity)
+	if err != nil {
+		return time.Time{}, 0, LogEvent{}, err
+	}
+	processType, err := ParseProcessType(record.ProcessType)
+	if err != nil {
+		return time.Time{}, 0, LogEvent{}, err
+	}
+	return record.Timestamp, severity, LogEvent{
+		ProcessType: processType,
+		ProcessId:   record.ProcessId,
+		Event:       record.Event,
+	}, nil
+}
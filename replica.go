@@ -0,0 +1,86 @@
+package goutils
+
+import (
+	"fmt"
+	"log"
+)
+
+// WithMirrorDirectory writes a second copy of the log files to dir, e.g. on
+// another mount point so losing one disk does not lose the logs. Every line
+// is written to both copies; a copy failing is reported to OnError without
+// affecting the other, and only a line no copy accepted goes to stderr. A
+// directory that cannot be opened by NewLogger is reported to OnError as
+// well and the logger starts without that copy.
+// Mirror files are flushed, synced, rotated and reset with the primary files
+// and are listed by Files; the rotation hook is called for their backups too.
+// Can be given several times.
+func WithMirrorDirectory(dir string) Option {
+	return func(c *config) {
+		c.mirrorDirectories = append(c.mirrorDirectories, dir)
+	}
+}
+
+// openReplicas opens the files of every mirror directory and attaches them
+// to the primary sinks they copy, returning all of them. A directory that
+// cannot be opened is reported to OnError and left out, as a copy failing
+// later would be, so losing a mount does not prevent starting
+func openReplicas(cfg config, date string, logs *sink, errors *sink, logFilename string, errorFilename string) []*sink {
+	var replicas []*sink
+	for _, dir := range cfg.mirrorDirectories {
+		logsReplica, errorsReplica, err := openReplica(cfg, date, dir, logs, errors, logFilename, errorFilename)
+		if err != nil {
+			err = fmt.Errorf("mirror directory: %w", err)
+			cfg.onError(&WriteError{Op: "open", Path: dir, Severity: cfg.errorSeverity(err), Err: err})
+			continue
+		}
+		replicas = append(replicas, logsReplica)
+		logs.replicas = append(logs.replicas, logsReplica)
+		if logs == errors {
+			continue
+		}
+		replicas = append(replicas, errorsReplica)
+		errors.replicas = append(errors.replicas, errorsReplica)
+	}
+	return replicas
+}
+
+// openReplica opens the copies of logs and errors in dir, errorsReplica is
+// logsReplica when both share a file
+func openReplica(cfg config, date string, dir string, logs *sink, errors *sink, logFilename string, errorFilename string) (logsReplica *sink, errorsReplica *sink, err error) {
+	logsWriter, errorsWriter, err := openOutputFiles(cfg, date, dir, logFilename, errorFilename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logsReplica, err = newSink(logsWriter, cfg.bufferSize, cfg.header(), logs.severities...)
+	if err != nil {
+		if logs == errors {
+			closeWriters(logsWriter)
+		} else {
+			closeWriters(logsWriter, errorsWriter)
+		}
+		return nil, nil, err
+	}
+	logsReplica.stem = logs.stem
+	if logs == errors {
+		return logsReplica, logsReplica, nil
+	}
+
+	errorsReplica, err = newSink(errorsWriter, cfg.bufferSize, cfg.header(), errors.severities...)
+	if err != nil {
+		closeWriters(errorsWriter)
+		closeSinks([]*sink{logsReplica})
+		return nil, nil, err
+	}
+	errorsReplica.stem = errors.stem
+	return logsReplica, errorsReplica, nil
+}
+
+func closeSinks(sinks []*sink) {
+	for _, s := range sinks {
+		if err := s.close(); err != nil {
+			// log auto redirect to std err
+			log.Printf("error while closing %s: %v\n", s.path, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package goutils
+
+import (
+	"io"
+	"time"
+)
+
+// WithWriteRetry retries a failed write up to attempts times in total,
+// waiting backoff before the second attempt and doubling it after each
+// failure, so a transient EIO or EAGAIN on a network mount does not lose
+// lines. A write still failing is sent to stderr and reported to OnError.
+// Retries run under the write mutex, so every goroutine logging to the same
+// files blocks while a write waits; the waits of one write add up to at
+// most 1 second, after which it fails whatever attempts is.
+func WithWriteRetry(attempts int, backoff time.Duration) Option {
+	return func(c *config) {
+		c.writeAttempts = attempts
+		c.writeBackoff = backoff
+	}
+}
+
+func (c config) retrying(w io.WriteCloser) io.WriteCloser {
+	if c.writeAttempts <= 1 {
+		return w
+	}
+	return &retryWriter{WriteCloser: w, attempts: c.writeAttempts, backoff: c.writeBackoff}
+}
+
+// total time a single write may spend waiting between attempts
+const maxWriteRetryDelay = time.Second
+
+// retries below any buffer so buffered flushes are retried as well, bytes
+// already written are never written twice
+type retryWriter struct {
+	io.WriteCloser
+	attempts int
+	backoff  time.Duration
+}
+
+func (w *retryWriter) Write(p []byte) (int, error) {
+	written := 0
+	delay := w.backoff
+	waited := time.Duration(0)
+	for attempt := 1; ; attempt++ {
+		n, err := w.WriteCloser.Write(p[written:])
+		written += n
+		if err == nil && written == len(p) {
+			return written, nil
+		}
+		if err == nil {
+			err = io.ErrShortWrite
+		}
+		if attempt >= w.attempts || waited >= maxWriteRetryDelay {
+			return written, err
+		}
+		wait := min(delay, maxWriteRetryDelay-waited)
+		time.Sleep(wait)
+		waited += wait
+		delay *= 2
+	}
+}
@@ -0,0 +1,73 @@
+package goutils
+
+import "sync"
+
+// WithRingBuffer keeps the n most recent lines in memory, whatever happens
+// to the files, so they can be served by e.g. a /debug/logs endpoint. Lines
+// are kept before encryption.
+func WithRingBuffer(n int) Option {
+	return func(c *config) {
+		c.ringSize = n
+	}
+}
+
+// RecentLogs returns the most recent lines kept by WithRingBuffer, oldest
+// first, or nil when no ring buffer is configured.
+func (b *Blogger) RecentLogs() []string {
+	if b == nil || b.ring == nil {
+		return nil
+	}
+	return b.ring.items()
+}
+
+// WithReplayBuffer keeps the n most recent events in memory as records,
+// whichever file they went to and across rotations, for crash diagnostics
+// needing the parsed fields rather than the lines of WithRingBuffer.
+// Sampled events are not kept.
+func WithReplayBuffer(n int) Option {
+	return func(c *config) {
+		c.replaySize = n
+	}
+}
+
+// Replay returns the most recent events kept by WithReplayBuffer, oldest
+// first, or nil when no replay buffer is configured.
+func (b *Blogger) Replay() []LogRecord {
+	if b == nil || b.replay == nil {
+		return nil
+	}
+	return b.replay.items()
+}
+
+// fixed size circular buffer, O(1) per insert
+type ringBuffer[T any] struct {
+	mu    sync.Mutex
+	buf   []T
+	next  int // slot written by the next add
+	count int
+}
+
+func newRingBuffer[T any](n int) *ringBuffer[T] {
+	return &ringBuffer[T]{buf: make([]T, n)}
+}
+
+func (r *ringBuffer[T]) add(item T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buf[r.next] = item
+	r.next = (r.next + 1) % len(r.buf)
+	if r.count < len(r.buf) {
+		r.count++
+	}
+}
+
+func (r *ringBuffer[T]) items() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := make([]T, 0, r.count)
+	start := (r.next - r.count + len(r.buf)) % len(r.buf)
+	for i := 0; i < r.count; i++ {
+		items = append(items, r.buf[(start+i)%len(r.buf)])
+	}
+	return items
+}
@@ -0,0 +1,110 @@
+package goutils
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// RotationInterval is how long lines go to the same dated files, see
+// WithRotationInterval.
+type RotationInterval int
+
+const (
+	Daily  RotationInterval = iota // 2006-01-02-app_logs.csv
+	Hourly                         // 2006-01-02T15-app_logs.csv
+)
+
+func (r RotationInterval) layout() string {
+	if r == Hourly {
+		return "2006-01-02T15"
+	}
+	return "2006-01-02"
+}
+
+func (r RotationInterval) duration() time.Duration {
+	if r == Hourly {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// WithRotationInterval starts new dated files when the day (or the hour)
+// in the filenames changes, on the first line written afterwards; files are
+// named after the hour with Hourly. The finished files are left in place
+// under their name, compressed in the background with WithCompressionAlgo,
+// and the rotation hook is called with their final path. Without this
+// option the files opened by NewLogger are written until they are rotated,
+// whatever the date.
+func WithRotationInterval(interval RotationInterval) Option {
+	return func(c *config) {
+		c.rotationInterval = interval
+		c.rollover = true
+	}
+}
+
+// rollover must be called with the write mutex held, the files belong to
+// the original logger when b is a clone. It returns the errors to report
+// once the mutex is released
+func (b *Blogger) rollover() []error {
+	owner := b
+	if b.root != nil {
+		owner = b.root
+	}
+	date := b.cfg.fileDate()
+	if date == owner.date {
+		return nil
+	}
+	owner.date = date
+
+	var errs []error
+	for _, s := range owner.files() {
+		if s.closed || s.stem == "" {
+			continue
+		}
+		local := s.file != nil
+		oldPath := s.path
+		newPath := b.cfg.datedPath(filepath.Dir(oldPath), date, s.stem)
+		if err := s.close(); err != nil {
+			errs = append(errs, fmt.Errorf("error while closing %s: %w", oldPath, err))
+		}
+		w, err := b.cfg.openWriter(newPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w %s: %w", ErrLogFileOpen, newPath, err))
+			// keep writing to the previous file rather than losing logs
+			if w, err = b.cfg.openWriter(oldPath); err != nil {
+				continue
+			}
+		}
+		if err := s.reset(w); err != nil {
+			errs = append(errs, err)
+		}
+		if s.path != oldPath {
+			owner.writeMetadata(s)
+			owner.finishFile(oldPath, s.path, local)
+		}
+	}
+	owner.LogsFile = owner.logs.file
+	owner.ErrorsFile = owner.errors.file
+	return errs
+}
+
+// finishFile compresses a file left behind by a rollover when configured,
+// off the write path, then hands it to the rotation hook
+func (b *Blogger) finishFile(oldPath string, newPath string, local bool) {
+	if !local || b.cfg.liveCompression || b.cfg.compression == NoCompression {
+		b.runRotationHook(oldPath, newPath)
+		return
+	}
+
+	b.hooks.Add(1)
+	go func() {
+		defer b.hooks.Done()
+		finished, err := compressFile(oldPath, b.cfg.compression)
+		if err != nil {
+			b.cfg.onError(fmt.Errorf("error while compressing %s: %w", oldPath, err))
+			finished = oldPath
+		}
+		b.runRotationHook(finished, newPath)
+	}()
+}
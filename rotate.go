@@ -0,0 +1,140 @@
+package goutils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// timestamp appended to rotated files, sortable and safe for any filesystem
+const backupTimeFormat = "20060102T150405.000000000"
+
+// Rotate closes the active files, renames them to timestamped backups and
+// opens fresh ones in their place. Writes wait for the files to be swapped,
+// compression of the backups (if configured) runs after writes resume.
+// Writers from WithWriterFactory cannot be renamed, they are closed and the
+// factory is called again for the same name.
+func (b *Blogger) Rotate() error {
+	if b == nil {
+		return nil
+	}
+	if b.root != nil {
+		return b.root.Rotate()
+	}
+	rotations, err := b.rotateFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rotations {
+		backup := r.backup
+		if r.local && !b.cfg.liveCompression {
+			if backup, err = compressFile(r.backup, b.cfg.compression); err != nil {
+				return err
+			}
+		}
+		b.runRotationHook(backup, r.active)
+	}
+	return nil
+}
+
+// a file moved away by rotation and the path reopened in its place
+type rotation struct {
+	backup string
+	active string
+	local  bool // backup is a file on disk that can be compressed
+}
+
+func (b *Blogger) rotateFiles() ([]rotation, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer func() {
+		b.LogsFile = b.logs.file
+		b.ErrorsFile = b.errors.file
+	}()
+	if b.logs.closed {
+		return nil, ErrLoggerClosed
+	}
+
+	stamp := b.cfg.now().UTC().Format(backupTimeFormat)
+	renamed := make(map[string]bool)
+	var rotations []rotation
+	for _, s := range b.files() {
+		local := s.file != nil
+		backup, err := s.rotate(b.cfg, stamp, renamed)
+		if err != nil {
+			return rotations, err
+		}
+		b.writeMetadata(s)
+		if backup != "" {
+			rotations = append(rotations, rotation{backup: backup, active: s.path, local: local})
+		}
+	}
+	return rotations, nil
+}
+
+// the hook runs in its own goroutine so a slow upload never blocks the
+// caller of Rotate, a panicking hook is reported through OnError
+func (b *Blogger) runRotationHook(oldPath string, newPath string) {
+	if b.cfg.rotationHook == nil {
+		return
+	}
+
+	b.hooks.Add(1)
+	go func() {
+		defer b.hooks.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				b.cfg.onError(fmt.Errorf("rotation hook panicked for %s: %v", oldPath, r))
+			}
+		}()
+		b.cfg.rotationHook(oldPath, newPath)
+	}()
+}
+
+// rotate moves the active file to its backup path and reopens the original
+// path, renamed tracks paths already moved when both sinks share a file in
+// which case no backup path is returned
+func (s *sink) rotate(cfg config, stamp string, renamed map[string]bool) (string, error) {
+	path := s.path
+	local := s.file != nil
+	backup := ""
+
+	if err := s.close(); err != nil {
+		return "", err
+	}
+
+	if !renamed[path] {
+		backup = path
+		if local {
+			backup = backupPath(path, stamp)
+			if err := os.Rename(path, backup); err != nil {
+				// keep writing to the original file rather than losing logs
+				if w, openErr := cfg.openWriter(path); openErr == nil {
+					_ = s.reset(w)
+				}
+				return "", err
+			}
+		}
+		renamed[path] = true
+	}
+
+	w, err := cfg.openWriter(path)
+	if err != nil {
+		return "", err
+	}
+	if err := s.reset(w); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// e.g. 2006-01-02-app_logs.csv -> 2006-01-02-app_logs.20060102T150405.000000000.csv,
+// live compressed files keep their .csv.gz extension
+func backupPath(path string, stamp string) string {
+	ext := ".csv"
+	if strings.HasSuffix(path, ".csv.gz") {
+		ext = ".csv.gz"
+	}
+	return strings.TrimSuffix(path, ext) + "." + stamp + ext
+}
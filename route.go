@@ -0,0 +1,49 @@
+package goutils
+
+import "io"
+
+// WithSeverityRoute pins a severity to its own writer (e.g. Notice to an
+// audit file) instead of the standard or error file. Severities without a
+// route keep the default split. The writer is flushed but never closed by
+// the logger and it is not affected by rotation.
+func WithSeverityRoute(severity Severity, w io.Writer) Option {
+	return func(c *config) {
+		if c.routes == nil {
+			c.routes = make(map[Severity]io.Writer)
+		}
+		c.routes[severity] = w
+	}
+}
+
+// unrouted filters out the severities pinned to their own writer
+func (c config) unrouted(severities ...Severity) []Severity {
+	var kept []Severity
+	for _, severity := range severities {
+		if _, ok := c.routes[severity]; !ok {
+			kept = append(kept, severity)
+		}
+	}
+	return kept
+}
+
+func newRouteSinks(c config) (map[Severity]*sink, error) {
+	if len(c.routes) == 0 {
+		return nil, nil
+	}
+
+	routes := make(map[Severity]*sink, len(c.routes))
+	for severity, w := range c.routes {
+		dest, err := newSink(&namedWriter{WriteCloser: c.retrying(nopCloser{w})}, c.bufferSize, "", severity)
+		if err != nil {
+			return nil, err
+		}
+		routes[severity] = dest
+	}
+	return routes, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
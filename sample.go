@@ -0,0 +1,147 @@
+package goutils
+
+import (
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type reservoirConfig struct {
+	severity Severity
+	size     int
+	interval time.Duration
+}
+
+// WithReservoir keeps a uniform random sample of at most size events of the
+// given severity per interval and discards the rest. Unlike 1-in-N sampling,
+// bursts are represented proportionally. Sampled events keep the timestamp
+// they were logged with and are written in their original order when the
+// interval ends (or on Close).
+func WithReservoir(severity Severity, size int, flushInterval time.Duration) Option {
+	return func(c *config) {
+		if size <= 0 || flushInterval <= 0 {
+			return
+		}
+		c.reservoir = &reservoirConfig{severity: severity, size: size, interval: flushInterval}
+	}
+}
+
+// WithDrainTimeout bounds how long Close spends writing the events still
+// held by the reservoir to a slow destination. Events left when it expires
+// are abandoned, counted in DropStats and reported as ErrDrainIncomplete.
+// Zero, the default, writes them all.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.drainTimeout = d
+	}
+}
+
+type sampledLine struct {
+	seq  uint64 // arrival order within the interval
+	line string
+}
+
+// reservoir sampling (algorithm R) over the lines of a single severity
+type reservoir struct {
+	severity Severity
+	size     int
+	dropped  *atomic.Uint64
+
+	mu     sync.Mutex
+	seen   uint64
+	sample []sampledLine
+}
+
+func (r *reservoir) offer(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seen++
+	if len(r.sample) < r.size {
+		r.sample = append(r.sample, sampledLine{seq: r.seen, line: line})
+		return
+	}
+	// past the reservoir size every offer discards exactly one line
+	r.dropped.Add(1)
+	if i := rand.Uint64N(r.seen); i < uint64(r.size) {
+		r.sample[i] = sampledLine{seq: r.seen, line: line}
+	}
+}
+
+// drain returns the current sample in arrival order and starts a new interval
+func (r *reservoir) drain() []sampledLine {
+	r.mu.Lock()
+	sample := r.sample
+	r.sample = make([]sampledLine, 0, r.size)
+	r.seen = 0
+	r.mu.Unlock()
+
+	sort.Slice(sample, func(i, j int) bool { return sample[i].seq < sample[j].seq })
+	return sample
+}
+
+func (b *Blogger) startReservoir(cfg reservoirConfig) {
+	b.reservoir = &reservoir{severity: cfg.severity, size: cfg.size, dropped: &b.drops.sampled}
+
+	b.background.Add(1)
+	go func() {
+		defer b.background.Done()
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				b.flushReservoir(time.Time{})
+			case <-b.done:
+				if abandoned := b.flushReservoir(b.drainDeadline()); abandoned > 0 {
+					b.drops.abandoned.Add(abandoned)
+					b.drainAbandoned.Store(abandoned)
+				}
+				return
+			}
+		}
+	}()
+}
+
+// the earliest of the drain timeout and the deadline of the closing
+// context, now when it is already done
+func (b *Blogger) drainDeadline() time.Time {
+	var deadline time.Time
+	if b.cfg.drainTimeout > 0 {
+		deadline = time.Now().Add(b.cfg.drainTimeout)
+	}
+	if b.closing == nil {
+		return deadline
+	}
+	if b.closing.Err() != nil {
+		return time.Now()
+	}
+	if d, ok := b.closing.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		return d
+	}
+	return deadline
+}
+
+// flushReservoir writes the current sample, lines still pending once the
+// deadline (if not zero) has passed are abandoned and their count returned
+func (b *Blogger) flushReservoir(deadline time.Time) uint64 {
+	abandoned, errs := b.writeSample(b.reservoir.drain(), deadline)
+	b.cfg.report(errs)
+	return abandoned
+}
+
+func (b *Blogger) writeSample(sample []sampledLine, deadline time.Time) (uint64, []error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []error
+	for i, sampled := range sample {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return uint64(len(sample) - i), errs
+		}
+		errs = append(errs, b.writeLine(b.reservoir.severity, sampled.line, nil)...)
+	}
+	return 0, errs
+}
@@ -0,0 +1,106 @@
+package goutils
+
+import (
+	"fmt"
+	"time"
+)
+
+// how often the clock is compared with the schedule
+const scheduleCheckInterval = 250 * time.Millisecond
+
+// WithRotationSchedule rotates the files at the given wall-clock times,
+// "HH:MM" in the zone of the filenames (see WithFilenameTimeZone), e.g.
+// []string{"00:00", "12:00"}. The clock is checked a few times a second,
+// so a jump of the clock fires a time it skipped over once, and a clock
+// going back never fires a time twice, nor does a time repeated by a DST
+// change; a time skipped by a DST change fires when the clock jumps over it.
+// The scheduler stops on Close. NewLogger rejects a malformed time.
+func WithRotationSchedule(times []string) Option {
+	return func(c *config) {
+		c.rotationSchedule = append([]string(nil), times...)
+	}
+}
+
+// hour and minute of a scheduled rotation
+type clockTime struct {
+	hour, minute int
+}
+
+func parseSchedule(times []string) ([]clockTime, error) {
+	schedule := make([]clockTime, 0, len(times))
+	for _, s := range times {
+		t, err := time.Parse("15:04", s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rotation time %q, expected HH:MM", s)
+		}
+		schedule = append(schedule, clockTime{hour: t.Hour(), minute: t.Minute()})
+	}
+	return schedule, nil
+}
+
+func (b *Blogger) startRotationSchedule(schedule []clockTime) {
+	b.background.Add(1)
+	go func() {
+		defer b.background.Done()
+		ticker := time.NewTicker(scheduleCheckInterval)
+		defer ticker.Stop()
+
+		checked := b.cfg.now()
+		for {
+			select {
+			case <-ticker.C:
+				now := b.cfg.now()
+				if !now.After(checked) {
+					continue
+				}
+				if scheduledBetween(schedule, checked, now, b.cfg.fileLocation()) {
+					if err := b.Rotate(); err != nil {
+						b.cfg.onError(err)
+					}
+				}
+				checked = now
+			case <-b.done:
+				return
+			}
+		}
+	}()
+}
+
+// on returns the instant of t on the given day in loc; a time skipped by a
+// DST change happens when the clock jumps over it, like in cron
+func (t clockTime) on(day time.Time, loc *time.Location) time.Time {
+	at := time.Date(day.Year(), day.Month(), day.Day(), t.hour, t.minute, 0, 0, loc)
+	if wall := at.In(loc); wall.Hour() == t.hour && wall.Minute() == t.minute {
+		return at
+	}
+
+	// the change lies between t read with the offset before it and after it
+	_, before := at.Add(-12 * time.Hour).In(loc).Zone()
+	_, after := at.Add(12 * time.Hour).In(loc).Zone()
+	lo := time.Date(day.Year(), day.Month(), day.Day(), t.hour, t.minute, 0, 0, time.FixedZone("", after))
+	hi := time.Date(day.Year(), day.Month(), day.Day(), t.hour, t.minute, 0, 0, time.FixedZone("", before))
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, offset := mid.In(loc).Zone(); offset == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi.Truncate(time.Second)
+}
+
+// scheduledBetween reports whether a scheduled time falls in (after, upTo],
+// the times of each day are resolved in loc so DST changes are accounted for
+func scheduledBetween(schedule []clockTime, after time.Time, upTo time.Time, loc *time.Location) bool {
+	start := after.In(loc)
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); !day.After(upTo); day = day.AddDate(0, 0, 1) {
+		for _, t := range schedule {
+			at := t.on(day, loc)
+			if at.After(after) && !at.After(upTo) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package goutils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// version of the column layout declared by the schema header, bumped
+// whenever the set or order of the columns written by format changes
+const schemaVersion = 1
+
+// opens the schema header, the parser skips any line starting with it
+const schemaHeaderPrefix = "# go-utils log v"
+
+// WithSchemaHeader writes a comment line declaring the layout version and
+// the columns, e.g. "# go-utils log v1 columns: severity,timestamp,...",
+// at the top of every new file (including the fresh file after a rotation)
+// so files written by different versions can be told apart. ParseReader
+// names the columns of the lines that follow after it, and ParseSchemaHeader
+// reads it back. The header is written in clear even with WithEncryption.
+// A file reopened after a restart keeps its header, and NewLogger fails with
+// ErrSchemaMismatch if it declares other columns than configured.
+func WithSchemaHeader() Option {
+	return func(c *config) {
+		c.schemaHeader = true
+	}
+}
+
+// what opens every new file: the byte-order mark and the terminated header
+// line, empty when both are disabled
+func (c config) header() string {
+	bom := ""
+	if c.utf8BOM {
+		bom = utf8BOM
+	}
+	if !c.schemaHeader {
+		return bom
+	}
+	return fmt.Sprintf("%s%s%d columns: %s%s", bom, schemaHeaderPrefix, schemaVersion, strings.Join(c.columnNames(), ","), c.lineEnding)
+}
+
+func (c config) columnNames() []string {
+	names := make([]string, 0, len(c.columns()))
+	for _, column := range c.columns() {
+		names = append(names, column.ToString())
+	}
+	return names
+}
+
+// longest header line looked for at the top of an existing file
+const maxHeaderBytes = 4096
+
+// checkSchema compares the schema header of a file that already has lines,
+// e.g. reopened after a restart, with the configured columns so lines of a
+// different layout are never appended below it. Files without a header
+// cannot be checked and are accepted.
+func (c config) checkSchema(file *os.File) error {
+	stat, err := file.Stat()
+	if err != nil || stat.Size() == 0 || c.lineEnding == "" {
+		return nil
+	}
+	r, err := decompressed(io.NewSectionReader(file, 0, stat.Size()))
+	if err != nil {
+		return nil
+	}
+	top, _ := io.ReadAll(io.LimitReader(skipBOM(r), maxHeaderBytes))
+	line, _, _ := strings.Cut(string(top), c.lineEnding)
+
+	version, columns, ok := ParseSchemaHeader(line)
+	if !ok {
+		return nil
+	}
+	if version != schemaVersion || !slices.Equal(columns, c.columnNames()) {
+		return fmt.Errorf("%w: the file declares v%d columns %s, configured v%d columns %s", ErrSchemaMismatch,
+			version, strings.Join(columns, ","), schemaVersion, strings.Join(c.columnNames(), ","))
+	}
+	return nil
+}
+
+// ParseSchemaHeader returns the layout version and the columns declared by
+// a header line written with WithSchemaHeader, ok is false for any other line.
+func ParseSchemaHeader(line string) (version int, columns []string, ok bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, schemaHeaderPrefix) {
+		return 0, nil, false
+	}
+	declared, list, found := strings.Cut(strings.TrimPrefix(line, schemaHeaderPrefix), " columns: ")
+	if !found {
+		return 0, nil, false
+	}
+	if _, err := fmt.Sscanf(declared, "%d", &version); err != nil {
+		return 0, nil, false
+	}
+	return version, strings.Split(list, ","), true
+}
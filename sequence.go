@@ -0,0 +1,53 @@
+package goutils
+
+import (
+	"strconv"
+	"sync"
+)
+
+// WithSequence appends a "seq" column numbering the lines written within
+// the same second from 0, so timestamp and seq together order lines
+// strictly even when many share a timestamp. Every second has its own
+// counter, so lines logged with LogAt at an earlier second continue its
+// numbering; counters are kept for the last 1024 seconds seen. Sampled
+// lines are numbered when they are logged, like their timestamp.
+func WithSequence() Option {
+	return func(c *config) {
+		c.sequence = true
+	}
+}
+
+// seconds whose counter is kept, older ones are forgotten first
+const sequenceSeconds = 1024
+
+// per-second counters shared by a logger and its clones
+type sequencer struct {
+	mu      sync.Mutex
+	next    map[string]uint64 // by formatted timestamp
+	seconds []string          // keys of next, oldest first
+}
+
+func (s *sequencer) number(timestamp string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.next[timestamp]
+	if !ok {
+		if s.next == nil {
+			s.next = make(map[string]uint64)
+		}
+		if len(s.seconds) == sequenceSeconds {
+			delete(s.next, s.seconds[0])
+			s.seconds = s.seconds[1:]
+		}
+		s.seconds = append(s.seconds, timestamp)
+	}
+	s.next[timestamp] = n + 1
+	return strconv.FormatUint(n, 10)
+}
+
+// peek returns the number the next line at timestamp would take
+func (s *sequencer) peek(timestamp string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strconv.FormatUint(s.next[timestamp], 10)
+}
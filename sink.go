@@ -0,0 +1,362 @@
+package goutils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// FileInfo describes one of the files a logger is currently writing to.
+type FileInfo struct {
+	Path       string
+	Size       int64      // bytes, including content found when the file was opened
+	Severities []Severity // severities routed to this file
+}
+
+// WithSingleFile writes every severity to the standard log file through a
+// single handle, no error file is opened and ErrorsFile is LogsFile.
+func WithSingleFile() Option {
+	return func(c *config) {
+		c.singleFile = true
+	}
+}
+
+// WithFlatRouting sends every severity to the standard log file instead of
+// moving Emergency, Alert and Critical to the error file, for consumers
+// filtering on the severity column themselves. Only the routing policy
+// changes: no error file is needed so none is created, and Layout reports
+// Flat rather than SingleFile.
+func WithFlatRouting() Option {
+	return func(c *config) {
+		c.flatRouting = true
+	}
+}
+
+// a single file is opened for every severity
+func (c config) oneFile() bool {
+	return c.singleFile || c.flatRouting
+}
+
+// WithWriterFactory replaces os.OpenFile as the way destinations are opened,
+// e.g. to back logs with an S3 uploader or a network connection while keeping
+// routing, formatting and rotation. The factory receives the path the logger
+// would otherwise create and is called again with it on every rotation; the
+// log directory is not created and LogsFile/ErrorsFile stay nil.
+func WithWriterFactory(factory func(name string) (io.WriteCloser, error)) Option {
+	return func(c *config) {
+		c.writerFactory = factory
+	}
+}
+
+// destination opened either as a local file or through the writer factory
+type namedWriter struct {
+	io.WriteCloser
+	name string
+	file *os.File // nil for custom writers
+}
+
+func (c config) openWriter(name string) (*namedWriter, error) {
+	if c.writerFactory != nil {
+		w, err := c.writerFactory(name)
+		if err != nil {
+			return nil, err
+		}
+		return &namedWriter{WriteCloser: c.compressing(c.retrying(w)), name: name}, nil
+	}
+
+	file, err := openLogFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if c.exclusiveLock {
+		if err := lockFile(file); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if err := c.checkSchema(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &namedWriter{WriteCloser: c.compressing(c.retrying(file)), name: name, file: file}, nil
+}
+
+// output of a group of severities, size is tracked on every write
+// so it can be reported without stat-ing the file
+type sink struct {
+	path       string
+	w          io.WriteCloser
+	file       *os.File      // nil for custom writers
+	buf        *bufio.Writer // nil unless buffering is enabled
+	size       int64         // includes bytes still in buf
+	severities []Severity
+	header     string  // written first to every empty file, may be empty
+	closed     bool    // until reset to a fresh destination
+	replicas   []*sink // copies written along with this sink
+	stem       string  // filename after the date, empty for routes
+}
+
+func newSink(w *namedWriter, bufferSize int, header string, severities ...Severity) (*sink, error) {
+	s := &sink{severities: severities, header: header}
+	if bufferSize > 0 {
+		s.buf = bufio.NewWriterSize(w, bufferSize)
+	}
+	if err := s.reset(w); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *sink) write(line string) error {
+	if s.closed {
+		return ErrLoggerClosed
+	}
+	if s.buf != nil && len(line) > s.buf.Available() {
+		// bufio would write the part that fits along with the previous lines
+		// and the rest later, a line always goes out in one write instead
+		if err := s.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	var n int
+	var err error
+	if s.buf != nil && len(line) <= s.buf.Available() {
+		n, err = s.buf.WriteString(line)
+	} else {
+		n, err = io.WriteString(s.w, line)
+	}
+	s.size += int64(n)
+	return err
+}
+
+func (s *sink) flush() error {
+	if s.closed {
+		return ErrLoggerClosed
+	}
+	if s.buf != nil {
+		if err := s.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (s *sink) sync() error {
+	if err := s.flush(); err != nil {
+		return err
+	}
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Sync()
+}
+
+func (s *sink) close() error {
+	if s.closed {
+		return ErrLoggerClosed
+	}
+	err := s.flush()
+	s.closed = true
+	if err != nil {
+		s.w.Close()
+		return err
+	}
+	return s.w.Close()
+}
+
+// reset points the sink to a freshly opened destination, buffered content
+// must have been flushed beforehand
+func (s *sink) reset(w *namedWriter) error {
+	var size int64
+	if w.file != nil {
+		stat, err := w.file.Stat()
+		if err != nil {
+			return err
+		}
+		size = stat.Size()
+	}
+
+	s.path = w.name
+	s.closed = false
+	s.w = w.WriteCloser
+	s.file = w.file
+	s.size = size
+	if s.buf != nil {
+		s.buf.Reset(w)
+	}
+	return s.writeHeader()
+}
+
+// a file that already has lines (e.g. reopened on restart) keeps its header
+func (s *sink) writeHeader() error {
+	if s.header == "" || s.size > 0 {
+		return nil
+	}
+	return s.write(s.header)
+}
+
+// truncate empties the file, lines still buffered are discarded
+func (s *sink) truncate() error {
+	if s.file == nil {
+		return fmt.Errorf("cannot truncate %s, it is not a local file", s.path)
+	}
+	if s.buf != nil {
+		s.buf.Reset(s.w)
+	}
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if gz, ok := s.w.(*liveGzip); ok {
+		gz.restart()
+	}
+	s.size = 0
+	return s.writeHeader()
+}
+
+func (s *sink) info() FileInfo {
+	return FileInfo{
+		Path:       s.path,
+		Size:       s.size,
+		Severities: append([]Severity(nil), s.severities...),
+	}
+}
+
+// Files reports the active log files together with their current size.
+func (b *Blogger) Files() []FileInfo {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var files []FileInfo
+	for _, s := range b.files() {
+		files = append(files, s.info())
+	}
+	return files
+}
+
+// the standard and error sinks, a single one with WithSingleFile, followed
+// by their copies in mirror directories
+func (b *Blogger) files() []*sink {
+	files := []*sink{b.logs}
+	if b.errors != b.logs {
+		files = append(files, b.errors)
+	}
+	return append(files, b.replicas...)
+}
+
+// every sink including routes
+func (b *Blogger) sinks() []*sink {
+	sinks := b.files()
+	for _, dest := range b.routes {
+		sinks = append(sinks, dest)
+	}
+	return sinks
+}
+
+// Reset truncates the log files to zero length, discarding everything
+// logged so far including buffered lines, so a test suite can reuse one
+// logger between cases. It is destructive and meant for tests only. Routes
+// and writers from WithWriterFactory are left untouched, the latter make
+// Reset fail.
+func (b *Blogger) Reset() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var errs []error
+	for _, s := range b.files() {
+		errs = append(errs, s.truncate())
+	}
+	return errors.Join(errs...)
+}
+
+// RoutingMode tells which file the severities are written to.
+type RoutingMode int
+
+const (
+	SeparateFiles RoutingMode = iota // Emergency, Alert and Critical in the error file
+	SingleFile                       // both kinds of lines share the standard file
+	Flat                             // every severity routed to the standard file
+	Routed                           // some severities written to WithSeverityRoute writers
+)
+
+var routingModeName = map[RoutingMode]string{
+	SeparateFiles: "separate",
+	SingleFile:    "single",
+	Flat:          "flat",
+	Routed:        "routed",
+}
+
+func (m RoutingMode) ToString() string {
+	return routingModeName[m]
+}
+
+// Layout describes where a logger writes, for tools reading the files back.
+type Layout struct {
+	Mode       RoutingMode
+	LogsPath   string
+	ErrorsPath string     // LogsPath with SingleFile and Flat
+	Replicas   []string   // copies in mirror directories
+	Routed     []Severity // written to WithSeverityRoute writers instead of the files
+}
+
+// Layout reports the routing mode and the active paths. Any WithSeverityRoute
+// gives Routed, whatever the files; otherwise WithFlatRouting gives Flat, and
+// WithSingleFile or an error filename equal to the log filename SingleFile.
+func (b *Blogger) Layout() Layout {
+	if b == nil {
+		return Layout{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	layout := Layout{Mode: SeparateFiles, LogsPath: b.logs.path, ErrorsPath: b.errors.path}
+	switch {
+	case len(b.routes) > 0:
+		layout.Mode = Routed
+	case b.cfg.flatRouting:
+		layout.Mode = Flat
+	case b.errors == b.logs:
+		layout.Mode = SingleFile
+	}
+	for _, replica := range b.replicas {
+		layout.Replicas = append(layout.Replicas, replica.path)
+	}
+	for severity := range b.routes {
+		layout.Routed = append(layout.Routed, severity)
+	}
+	slices.Sort(layout.Routed)
+	return layout
+}
+
+// HasSeparateErrorFile reports whether an error file is open next to the
+// standard one, for Emergency, Alert and Critical unless they are routed.
+func (b *Blogger) HasSeparateErrorFile() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.errors != b.logs
+}
+
+// Paths returns the resolved paths of the standard and error log files, the
+// dated names NewLogger built from its arguments.
+func (b *Blogger) Paths() (logs string, errors string) {
+	if b == nil {
+		return "", ""
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.logs.path, b.errors.path
+}
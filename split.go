@@ -0,0 +1,72 @@
+package goutils
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// opens the event of every line after the first of a split event
+const ContinuationMarker = "…[continued] "
+
+// WithMaxLineSize splits events whose line would exceed n bytes, line ending
+// included, into several lines of at most n bytes. They share the other
+// fields (timestamp and sequence number too) and every line after the first
+// starts its event with ContinuationMarker. Lines are measured before
+// encryption and sampled lines are never split; n too small for the other
+// fields disables splitting.
+//
+// Each line is handed to the file in a single write, also with WithBuffering
+// where a line is never split across two flushes, so with O_APPEND lines of
+// processes sharing a file do not interleave. Pipes only keep writes of up
+// to PIPE_BUF bytes (4096 on Linux) whole, set n to it when writing to one.
+func WithMaxLineSize(n int) Option {
+	return func(c *config) {
+		c.maxLineSize = n
+	}
+}
+
+// split returns the lines to write for fields, a single one unless the event
+// has to be split
+func (b *Blogger) split(severity Severity, fields []string) []string {
+	line := b.line(severity, fields)
+	size := len(line) + len(b.cfg.lineEnding)
+	event := slices.Index(b.cfg.columns(), EventColumn)
+	if b.cfg.maxLineSize <= 0 || size <= b.cfg.maxLineSize || event < 0 {
+		return []string{line}
+	}
+
+	// the event may gain quotes once cut, keep room for them
+	text := fields[event]
+	budget := b.cfg.maxLineSize - (size - len(text)) - len(ContinuationMarker) - 2
+	if budget < 2*utf8.UTFMax {
+		return []string{line}
+	}
+	var lines []string
+	for first := true; text != ""; first = false {
+		piece := cutQuoted(text, budget)
+		text = text[len(piece):]
+		if !first {
+			piece = ContinuationMarker + piece
+		}
+		fields[event] = piece
+		lines = append(lines, b.line(severity, fields))
+	}
+	return lines
+}
+
+// the longest prefix of s taking at most n bytes once its quotes are doubled,
+// never splitting a multibyte rune
+func cutQuoted(s string, n int) string {
+	used := 0
+	for i, r := range s {
+		size := utf8.RuneLen(r)
+		if r == '"' {
+			size++
+		}
+		if used+size > n {
+			return s[:i]
+		}
+		used += size
+	}
+	return s
+}
@@ -0,0 +1,200 @@
+package goutils
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DropStats counts events that were accepted by the logger but never written.
+type DropStats struct {
+	Sampled   uint64 // discarded by reservoir sampling
+	Cancelled uint64 // skipped by LogContext because the context was done
+	Abandoned uint64 // left in the reservoir when the drain timeout expired
+	Invalid   uint64 // rejected by event validation
+
+	Subscriber uint64 // written but missed by a Subscribe channel that was full
+}
+
+type dropCounters struct {
+	sampled   atomic.Uint64
+	cancelled atomic.Uint64
+	abandoned atomic.Uint64
+	invalid   atomic.Uint64
+
+	subscriber atomic.Uint64
+}
+
+// WriteStats measures what has been written to the files, e.g. to size
+// rotation thresholds.
+type WriteStats struct {
+	BytesWritten map[Severity]uint64 // line endings included, copies in mirror directories excluded
+	MaxLineBytes int                 // longest line written
+}
+
+// updated without the write mutex, which may be shared with other loggers
+type writeCounters struct {
+	bytes   [Trace + 1]atomic.Uint64
+	maxLine atomic.Int64
+}
+
+func (c *writeCounters) add(severity Severity, n int) {
+	if severity >= 0 && int(severity) < len(c.bytes) {
+		c.bytes[severity].Add(uint64(n))
+	}
+	for {
+		longest := c.maxLine.Load()
+		if int64(n) <= longest || c.maxLine.CompareAndSwap(longest, int64(n)) {
+			return
+		}
+	}
+}
+
+// DropStats returns how many events have been dropped so far, by reason.
+func (b *Blogger) DropStats() DropStats {
+	if b == nil {
+		return DropStats{}
+	}
+	return DropStats{
+		Sampled:   b.drops.sampled.Load(),
+		Cancelled: b.drops.cancelled.Load(),
+		Abandoned: b.drops.abandoned.Load(),
+		Invalid:   b.drops.invalid.Load(),
+
+		Subscriber: b.drops.subscriber.Load(),
+	}
+}
+
+// Stats returns how many events of each severity have been logged since the
+// logger was created or since the last ResetStats.
+func (b *Blogger) Stats() map[Severity]uint64 {
+	if b == nil {
+		return nil
+	}
+	return b.counts.totals()
+}
+
+// WriteStats returns how many bytes of each severity have been written and
+// the longest line so far, since the logger was created or the last
+// ResetStats. Lines that could not be written are not counted.
+func (b *Blogger) WriteStats() WriteStats {
+	if b == nil {
+		return WriteStats{}
+	}
+	stats := WriteStats{BytesWritten: make(map[Severity]uint64), MaxLineBytes: int(b.written.maxLine.Load())}
+	for severity := range b.written.bytes {
+		if n := b.written.bytes[severity].Load(); n > 0 {
+			stats.BytesWritten[Severity(severity)] = n
+		}
+	}
+	return stats
+}
+
+// WindowStats returns how many events of each severity were logged during
+// the last d, e.g. to detect error spikes. Counts are kept per second for at
+// most an hour, longer windows report the last hour.
+func (b *Blogger) WindowStats(d time.Duration) map[Severity]uint64 {
+	if b == nil {
+		return nil
+	}
+	return b.counts.window(b.cfg.now(), d)
+}
+
+// ResetStats zeroes the counters behind Stats, WindowStats, WriteStats and
+// DropStats.
+func (b *Blogger) ResetStats() {
+	if b == nil {
+		return
+	}
+	b.counts.reset()
+	b.drops.sampled.Store(0)
+	b.drops.cancelled.Store(0)
+	b.drops.abandoned.Store(0)
+	b.drops.invalid.Store(0)
+	b.drops.subscriber.Store(0)
+	for severity := range b.written.bytes {
+		b.written.bytes[severity].Store(0)
+	}
+	b.written.maxLine.Store(0)
+}
+
+// seconds of history kept for WindowStats
+const statsWindowSeconds = 60 * 60
+
+// events logged per severity, in total and per second over the last hour
+type severityCounts struct {
+	mu      sync.Mutex
+	total   map[Severity]uint64
+	buckets [statsWindowSeconds]countsBucket
+}
+
+type countsBucket struct {
+	second int64 // unix second the counts belong to
+	counts map[Severity]uint64
+}
+
+func (c *severityCounts) add(now time.Time, severity Severity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.total == nil {
+		c.total = make(map[Severity]uint64)
+	}
+	c.total[severity]++
+
+	second := now.Unix()
+	bucket := c.bucket(second)
+	if bucket.counts == nil {
+		bucket.counts = make(map[Severity]uint64)
+	}
+	if bucket.second != second {
+		// the slot still holds counts from an hour ago or more
+		clear(bucket.counts)
+		bucket.second = second
+	}
+	bucket.counts[severity]++
+}
+
+// slot of the given unix second, clocks set before 1970 included
+func (c *severityCounts) bucket(second int64) *countsBucket {
+	return &c.buckets[(second%statsWindowSeconds+statsWindowSeconds)%statsWindowSeconds]
+}
+
+func (c *severityCounts) totals() map[Severity]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	totals := make(map[Severity]uint64, len(c.total))
+	for severity, n := range c.total {
+		totals[severity] = n
+	}
+	return totals
+}
+
+func (c *severityCounts) window(now time.Time, d time.Duration) map[Severity]uint64 {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	seconds = max(0, min(seconds, statsWindowSeconds))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[Severity]uint64)
+	last := now.Unix()
+	for second := last - seconds + 1; second <= last; second++ {
+		bucket := c.bucket(second)
+		if bucket.second != second {
+			continue
+		}
+		for severity, n := range bucket.counts {
+			counts[severity] += n
+		}
+	}
+	return counts
+}
+
+func (c *severityCounts) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total = nil
+	for i := range c.buckets {
+		c.buckets[i] = countsBucket{}
+	}
+}
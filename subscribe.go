@@ -0,0 +1,84 @@
+package goutils
+
+import "sync"
+
+// events buffered per subscriber before further ones are dropped
+const subscriberBuffer = 64
+
+// channels returned by Subscribe, shared by a logger and its clones
+type subscribers struct {
+	mu     sync.Mutex
+	closed bool
+	subs   map[<-chan LogRecord]subscription
+}
+
+type subscription struct {
+	ch          chan LogRecord
+	minSeverity Severity
+}
+
+// Subscribe returns a channel receiving the events at or above minSeverity
+// as they are written, e.g. to open a circuit breaker on Critical events
+// without reading the files back. The channel buffers 64 events, events
+// logged while it is full are dropped for this subscriber and counted in
+// DropStats. Sampled events are not delivered. The channel is closed by
+// Unsubscribe or Close, and is returned closed after Close.
+func (b *Blogger) Subscribe(minSeverity Severity) <-chan LogRecord {
+	ch := make(chan LogRecord, subscriberBuffer)
+	if b == nil {
+		close(ch)
+		return ch
+	}
+	b.subscribers.mu.Lock()
+	defer b.subscribers.mu.Unlock()
+	if b.subscribers.closed {
+		close(ch)
+		return ch
+	}
+	if b.subscribers.subs == nil {
+		b.subscribers.subs = make(map[<-chan LogRecord]subscription)
+	}
+	b.subscribers.subs[ch] = subscription{ch: ch, minSeverity: minSeverity}
+	return ch
+}
+
+// Unsubscribe stops the delivery to ch, returned by Subscribe, and closes
+// it. Events still buffered can be drained.
+func (b *Blogger) Unsubscribe(ch <-chan LogRecord) {
+	if b == nil {
+		return
+	}
+	b.subscribers.mu.Lock()
+	defer b.subscribers.mu.Unlock()
+	if sub, ok := b.subscribers.subs[ch]; ok {
+		delete(b.subscribers.subs, ch)
+		close(sub.ch)
+	}
+}
+
+// publish never blocks the write path, full channels miss the event
+func (b *Blogger) publish(record LogRecord) {
+	b.subscribers.mu.Lock()
+	defer b.subscribers.mu.Unlock()
+	for _, sub := range b.subscribers.subs {
+		if !record.Severity.AtLeast(sub.minSeverity) {
+			continue
+		}
+		select {
+		case sub.ch <- record:
+		default:
+			b.drops.subscriber.Add(1)
+		}
+	}
+}
+
+// closeAll closes every channel, later subscriptions get a closed one
+func (s *subscribers) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for _, sub := range s.subs {
+		close(sub.ch)
+	}
+	s.subs = nil
+}
@@ -0,0 +1,82 @@
+package goutils__test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Backup Files
+// Rotated backups and previous days' files are listed newest first, active files excluded.
+func TestBackupFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	now := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(time.Minute)
+	}
+
+	// a file left by the previous day
+	yesterday := filepath.Join(tempDir, "2024-03-04-"+logsName+".csv")
+	if err := os.WriteFile(yesterday, []byte("old\n"), 0644); err != nil {
+		t.Fatalf("Failed to create previous day's file: %v", err)
+	}
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithClock(clock), goutils.WithCompressionAlgo(goutils.Gzip))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+	for i := 0; i < 2; i++ {
+		advance()
+		if err := logger.Rotate(); err != nil {
+			t.Fatalf("Rotate failed: %v", err)
+		}
+	}
+
+	backups, err := logger.BackupFiles()
+	if err != nil {
+		t.Fatalf("BackupFiles failed: %v", err)
+	}
+	// two rotations of both files, then the previous day's file
+	if len(backups) != 5 {
+		t.Fatalf("Expected 5 backups, got %+v", backups)
+	}
+	latest := time.Date(2024, time.March, 5, 10, 2, 0, 0, time.UTC)
+	for i, backup := range backups[:4] {
+		if !strings.HasSuffix(backup.Path, ".csv.gz") || backup.Size == 0 {
+			t.Errorf("Expected a non-empty gzip backup, got %+v", backup)
+		}
+		if want := latest.Add(-time.Duration(i/2) * time.Minute); !backup.Rotated.Equal(want) {
+			t.Errorf("Expected backup %d rotated at %s, got %s", i, want, backup.Rotated)
+		}
+	}
+	last := backups[4]
+	if last.Path != yesterday || !last.Rotated.IsZero() || !last.Date.Equal(time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the previous day's file last, got %+v", last)
+	}
+	logsPath, errorsPath := logger.Paths()
+	for _, backup := range backups {
+		if backup.Path == logsPath || backup.Path == errorsPath {
+			t.Errorf("Active file listed as a backup: %s", backup.Path)
+		}
+	}
+}
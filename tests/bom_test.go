@@ -0,0 +1,45 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: UTF-8 Byte-Order Mark
+// New files open with the mark, once, and the parser reads the first record past it.
+func TestWithUTF8BOM(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithUTF8BOM())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Café crème"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	content := readFile(t, logPath)
+	if !strings.HasPrefix(content, "\xef\xbb\xbfTRACE,") {
+		t.Errorf("Expected the mark right before the first record, got %q", content)
+	}
+	if count := strings.Count(content, "\xef\xbb\xbf"); count != 1 {
+		t.Errorf("Expected the mark once, found it %d times", count)
+	}
+
+	records, err := goutils.ParseLogFile(logPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Severity != goutils.Trace || records[1].Event != "Café crème" {
+		t.Errorf("Expected the mark ignored by the parser, got %+v", records)
+	}
+}
@@ -0,0 +1,158 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper to read a file the test expects to exist
+func readFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Could not read file at %s: %v", path, err)
+	}
+	return string(content)
+}
+
+// Test 1: Flush Threshold
+// Debug events stay in the buffer until a Notice forces the flush.
+func TestWithFlushThreshold(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithBuffering(64*1024),
+		goutils.WithFlushThreshold(goutils.Notice))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	debugMsg := "Buffered debug"
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: debugMsg})
+	if content := readFile(t, expectedLogPath); content != "" {
+		t.Fatalf("Expected nothing on disk before the flush. Got:\n%s", content)
+	}
+
+	noticeMsg := "Flushing notice"
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: noticeMsg})
+	content := readFile(t, expectedLogPath)
+	if !strings.Contains(content, debugMsg) || !strings.Contains(content, noticeMsg) {
+		t.Errorf("Expected both events on disk after the notice. Got:\n%s", content)
+	}
+}
+
+// Test 2: Default Flush Threshold
+// Error routed severities are flushed immediately while the rest waits for Flush.
+func TestBufferingDefaults(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithBuffering(64*1024))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	noticeMsg := "Buffered notice"
+	critMsg := "Immediate critical"
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: noticeMsg})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: critMsg})
+
+	if content := readFile(t, expectedErrPath); !strings.Contains(content, critMsg) {
+		t.Errorf("Expected critical on disk without a flush. Got:\n%s", content)
+	}
+	if content := readFile(t, expectedLogPath); strings.Contains(content, noticeMsg) {
+		t.Errorf("Expected notice to stay buffered. Got:\n%s", content)
+	}
+
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if content := readFile(t, expectedLogPath); !strings.Contains(content, noticeMsg) {
+		t.Errorf("Expected notice on disk after Flush. Got:\n%s", content)
+	}
+}
+
+// Test 3: Sync
+// Synced lines are readable from disk while the logger stays open.
+func TestSync(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithBuffering(64*1024))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	debugMsg := "Checkpointed debug"
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: debugMsg})
+	alertMsg := "Checkpointed alert"
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: alertMsg})
+
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if content := readFile(t, expectedLogPath); !strings.Contains(content, debugMsg) {
+		t.Errorf("Expected debug on disk after Sync. Got:\n%s", content)
+	}
+	if content := readFile(t, expectedErrPath); !strings.Contains(content, alertMsg) {
+		t.Errorf("Expected alert on disk after Sync. Got:\n%s", content)
+	}
+}
+
+// Test 4: Flush Interval
+// A buffered line reaches the file after the interval without an explicit flush.
+func TestWithFlushInterval(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	interval := 20 * time.Millisecond
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithBuffering(64*1024),
+		goutils.WithFlushInterval(interval))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	debugMsg := "Eventually visible"
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: debugMsg})
+
+	deadline := time.Now().Add(50 * interval)
+	for !strings.Contains(readFile(t, expectedLogPath), debugMsg) {
+		if time.Now().After(deadline) {
+			t.Fatal("Buffered line was not flushed by the interval")
+		}
+		time.Sleep(interval / 2)
+	}
+
+	// the ticker must stop cleanly
+	done := make(chan struct{})
+	go func() {
+		logger.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return, flush ticker still running")
+	}
+}
@@ -0,0 +1,74 @@
+package goutils__test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Cloned Loggers
+// A parent and its clone log concurrently to the same file, each with its own options (run with -race).
+func TestClone(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	parent, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	clone := parent.Clone(goutils.WithMinSeverity(goutils.Notice), goutils.WithServiceName("billing"))
+	if !parent.IsEnabled(goutils.Trace) || clone.IsEnabled(goutils.Debug) {
+		t.Fatal("Expected the clone min severity not to affect the parent")
+	}
+
+	var wg sync.WaitGroup
+	routines := 20
+	wg.Add(routines * 2)
+	for i := 0; i < routines; i++ {
+		for _, logger := range []*goutils.Blogger{parent, clone} {
+			go func(logger *goutils.Blogger, val int) {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					event := goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: fmt.Sprintf("%d-%d", val, j), Event: "Shared file"}
+					logger.Log(goutils.Notice, event)
+					logger.Log(goutils.Debug, event)
+				}
+			}(logger, i)
+		}
+	}
+	wg.Wait()
+
+	if err := clone.Close(); err != nil {
+		t.Fatalf("Closing the clone failed: %v", err)
+	}
+	// the files stay open for the parent
+	parent.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After clone close"})
+	if err := parent.Close(); err != nil {
+		t.Fatalf("Closing the parent failed: %v", err)
+	}
+
+	logsPath, _ := parent.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse the shared file: %v", err)
+	}
+	fromClone := 0
+	for _, record := range records {
+		if record.Extra["column6"] == "billing" {
+			fromClone++
+			if record.Severity != goutils.Notice {
+				t.Errorf("Expected only Notice lines from the clone, got %+v", record)
+			}
+		}
+	}
+	// parent: init, 2 lines per iteration and the final notice
+	if fromClone != routines*10 || len(records) != 1+routines*10*3+1 {
+		t.Errorf("Expected %d clone lines out of %d, got %d out of %d", routines*10, 2+routines*30, fromClone, len(records))
+	}
+}
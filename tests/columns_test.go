@@ -0,0 +1,209 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Reordered Columns
+// A reordered subset of the columns is written and parsed back with the same spec.
+func TestWithColumns(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	columns := []goutils.Column{goutils.TimestampColumn, goutils.SeverityColumn, goutils.EventColumn, goutils.ServiceColumn}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithColumns(columns), goutils.WithServiceName("billing"))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "7", Event: "Invoice sent"})
+	logsPath, _ := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	line := findLine(t, logsPath, "Invoice sent")
+	fields := strings.Split(line, ",")
+	if len(fields) != 4 || fields[1] != "DEBUG" || fields[2] != "Invoice sent" || fields[3] != "billing" {
+		t.Fatalf("Expected timestamp,severity,event,service, got %q", line)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath, goutils.ParseWithColumns(columns))
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	record := records[len(records)-1]
+	if record.Severity != goutils.Debug || record.Event != "Invoice sent" || record.Timestamp.IsZero() || record.Extra["service"] != "billing" {
+		t.Errorf("Unexpected record %+v", record)
+	}
+	if record.ProcessId != "" {
+		t.Errorf("Expected the omitted process id to stay empty, got %q", record.ProcessId)
+	}
+}
+
+// Test 2: Reordered Columns With Header
+// A schema header lets the parser read reordered columns without a spec.
+func TestWithColumnsSchemaHeader(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSchemaHeader(),
+		goutils.WithColumns([]goutils.Column{goutils.EventColumn, goutils.ProcessIdColumn, goutils.SeverityColumn}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "42", Event: "Started"})
+	logsPath, _ := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	record := records[len(records)-1]
+	if record.Severity != goutils.Notice || record.ProcessId != "42" || record.Event != "Started" {
+		t.Errorf("Unexpected record %+v", record)
+	}
+}
+
+// Test 3: Uptime Column
+// Two events logged an interval apart carry uptimes increasing by about that interval.
+func TestWithUptimeColumn(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithUptimeColumn(), goutils.WithSchemaHeader())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	interval := 50 * time.Millisecond
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "First"})
+	time.Sleep(interval)
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Second"})
+	logsPath, _ := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected init line and two events, got %d", len(records))
+	}
+	first, err := time.ParseDuration(records[1].Extra["uptime"])
+	if err != nil {
+		t.Fatalf("Could not parse uptime of %+v: %v", records[1], err)
+	}
+	second, err := time.ParseDuration(records[2].Extra["uptime"])
+	if err != nil {
+		t.Fatalf("Could not parse uptime of %+v: %v", records[2], err)
+	}
+	if delta := second - first; delta < interval || delta > interval+time.Second {
+		t.Errorf("Expected the uptime to grow by about %s, got %s", interval, delta)
+	}
+}
+
+// Test 4: Line Format Switch
+// Switching columns and delimiter while goroutines log never mixes formats
+// within a line, and the file opened by Rotate declares the new columns.
+func TestSetLineFormat(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSchemaHeader())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if err := logger.SetLineFormat(nil, '"'); !errors.Is(err, goutils.ErrInvalidDelimiter) {
+		t.Fatalf("Expected ErrInvalidDelimiter for a quote, got %v", err)
+	}
+
+	piped := []goutils.Column{goutils.TimestampColumn, goutils.SeverityColumn, goutils.EventColumn}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Worker"})
+			}
+		}()
+	}
+	for i := range 50 {
+		var err error
+		if i%2 == 0 {
+			err = logger.SetLineFormat(piped, '|')
+		} else {
+			err = logger.SetLineFormat(nil, 0)
+		}
+		if err != nil {
+			t.Fatalf("SetLineFormat failed: %v", err)
+		}
+	}
+	wg.Wait()
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	logsPath, _ := logger.Paths()
+	content, err := os.ReadFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not read file at %s: %v", logsPath, err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if !strings.Contains(line, "Worker") {
+			continue
+		}
+		commas, pipes := strings.Count(line, ","), strings.Count(line, "|")
+		if !(commas == 4 && pipes == 0) && !(commas == 0 && pipes == 2) {
+			t.Fatalf("Expected a line in either format, got %q", line)
+		}
+	}
+
+	if err := logger.SetLineFormat(piped, '|'); err != nil {
+		t.Fatalf("SetLineFormat failed: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After switch"})
+	logsPath, _ = logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	content, err = os.ReadFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not read file at %s: %v", logsPath, err)
+	}
+	header, _, _ := strings.Cut(string(content), "\n")
+	if _, columns, ok := goutils.ParseSchemaHeader(header); !ok || strings.Join(columns, ",") != "timestamp,severity,event" {
+		t.Fatalf("Expected the rotated file to declare the new columns, got %q", header)
+	}
+	if line := findLine(t, logsPath, "After switch"); !strings.HasSuffix(line, "|NOTICE|After switch") {
+		t.Errorf("Expected a pipe-delimited line, got %q", line)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !zstd
+
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Zstd Unavailable
+// Without the zstd build tag Zstd is rejected by NewLogger and zstd input by ParseLogFile.
+func TestZstdUnavailable(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	if _, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithCompressionAlgo(goutils.Zstd)); !errors.Is(err, goutils.ErrZstdUnavailable) {
+		t.Errorf("Expected ErrZstdUnavailable from NewLogger, got %v", err)
+	}
+
+	backup := filepath.Join(tempDir, "backup.csv.zst")
+	if err := os.WriteFile(backup, []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}, 0644); err != nil {
+		t.Fatalf("Could not write backup: %v", err)
+	}
+	if _, err := goutils.ParseLogFile(backup); !errors.Is(err, goutils.ErrZstdUnavailable) {
+		t.Errorf("Expected ErrZstdUnavailable from ParseLogFile, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package goutils__test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper to log a line, rotate and return the single compressed log backup
+func rotateCompressed(t *testing.T, algo goutils.CompressionAlgo, ext string, event string) string {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithCompressionAlgo(algo))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: event})
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	base := strings.TrimSuffix(expectedLogPath, ".csv")
+	if plain, _ := filepath.Glob(base + ".*.csv"); len(plain) != 0 {
+		t.Errorf("Uncompressed backups left behind: %v", plain)
+	}
+	backups, err := filepath.Glob(base + ".*.csv" + ext)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Expected one %s backup, got %v (%v)", ext, backups, err)
+	}
+	return backups[0]
+}
+
+// Test 1: Gzip Compression
+// Rotated backups are written as .gz and decompress to the original content.
+func TestGzipCompression(t *testing.T) {
+	event := "Compressed with gzip"
+	backup := rotateCompressed(t, goutils.Gzip, ".gz", event)
+
+	file, err := os.Open(backup)
+	if err != nil {
+		t.Fatalf("Could not open backup: %v", err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Could not create gzip reader: %v", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Could not decompress backup: %v", err)
+	}
+	if !strings.Contains(string(content), event) {
+		t.Errorf("Decompressed backup missing event. Got:\n%s", content)
+	}
+}
+
+// Test 2: Live Compression
+// The active .csv.gz file can be parsed after a flush while still open, and in full once closed.
+func TestWithLiveCompression(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithLiveCompression())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logsPath, _ := logger.Paths()
+	if !strings.HasSuffix(logsPath, ".csv.gz") {
+		t.Fatalf("Expected a .csv.gz file, got %s", logsPath)
+	}
+
+	for i := 0; i < 100; i++ {
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Compressed while written"})
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse the live file: %v", err)
+	}
+	if len(records) != 101 {
+		t.Errorf("Expected 101 flushed records, got %d", len(records))
+	}
+
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "2", Event: "Last line"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	records, err = goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse the closed file: %v", err)
+	}
+	if len(records) != 102 || records[101].Event != "Last line" {
+		t.Errorf("Expected every record once closed, got %d", len(records))
+	}
+	if stat, err := os.Stat(logsPath); err != nil || stat.Size() >= 102*50 {
+		t.Errorf("Expected the file to be compressed, got %v %v", stat, err)
+	}
+}
+
+// Test 3: Parse Compressed Backups
+// Rotated gzip backups are decompressed transparently by ParseLogFile.
+func TestParseCompressedBackups(t *testing.T) {
+	event := "Parsed from .gz"
+	backup := rotateCompressed(t, goutils.Gzip, ".gz", event)
+
+	records, err := goutils.ParseLogFile(backup)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 2 || records[1].Event != event {
+		t.Errorf("Expected init line and %q from the backup, got %+v", event, records)
+	}
+}
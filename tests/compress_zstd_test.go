@@ -0,0 +1,54 @@
+//go:build zstd
+
+package goutils__test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Test 1: Zstd Compression
+// Rotated backups are written as .zst and decompress to the original content.
+func TestZstdCompression(t *testing.T) {
+	event := "Compressed with zstd"
+	backup := rotateCompressed(t, goutils.Zstd, ".zst", event)
+
+	file, err := os.Open(backup)
+	if err != nil {
+		t.Fatalf("Could not open backup: %v", err)
+	}
+	defer file.Close()
+	decoder, err := zstd.NewReader(file)
+	if err != nil {
+		t.Fatalf("Could not create zstd reader: %v", err)
+	}
+	defer decoder.Close()
+
+	content, err := io.ReadAll(decoder)
+	if err != nil {
+		t.Fatalf("Could not decompress backup: %v", err)
+	}
+	if !strings.Contains(string(content), event) {
+		t.Errorf("Decompressed backup missing event. Got:\n%s", content)
+	}
+}
+
+// Test 2: Parse Zstd Backups
+// Rotated zstd backups are decompressed transparently by ParseLogFile.
+func TestParseZstdBackups(t *testing.T) {
+	event := "Parsed from .zst"
+	backup := rotateCompressed(t, goutils.Zstd, ".zst", event)
+
+	records, err := goutils.ParseLogFile(backup)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 2 || records[1].Event != event {
+		t.Errorf("Expected init line and %q from the backup, got %+v", event, records)
+	}
+}
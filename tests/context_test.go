@@ -0,0 +1,92 @@
+package goutils__test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Process From Context
+// Events logged with only a message take the process stored in the context, explicit ids win.
+func TestContextWithProcess(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	ctx := goutils.ContextWithProcess(context.Background(), goutils.RequestProcess, "req-42")
+	if err := logger.LogContext(ctx, goutils.Notice, goutils.LogEvent{Event: "From context"}); err != nil {
+		t.Fatalf("LogContext failed: %v", err)
+	}
+	if err := logger.LogContext(ctx, goutils.Notice, goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: "7", Event: "Explicit"}); err != nil {
+		t.Fatalf("LogContext failed: %v", err)
+	}
+	logger.Close()
+
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %+v", records)
+	}
+	if got := records[1]; got.ProcessType != goutils.RequestProcess || got.ProcessId != "req-42" {
+		t.Errorf("Expected the context process, got %+v", got)
+	}
+	if got := records[2]; got.ProcessType != goutils.GoRoutineProcess || got.ProcessId != "7" {
+		t.Errorf("Expected the explicit process, got %+v", got)
+	}
+}
+
+// Test 2: Generated Request IDs
+// ContextWithRequestID generates a non-empty id unique to every call when none is given.
+func TestContextWithRequestID(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	calls := 50
+	for i := 0; i < calls; i++ {
+		ctx := logger.ContextWithRequestID(context.Background(), "")
+		if err := logger.LogContext(ctx, goutils.Notice, goutils.LogEvent{Event: "Untagged request"}); err != nil {
+			t.Fatalf("LogContext failed: %v", err)
+		}
+	}
+	ctx := logger.ContextWithRequestID(context.Background(), "req-42")
+	_ = logger.LogContext(ctx, goutils.Notice, goutils.LogEvent{Event: "Tagged request"})
+	logger.Close()
+
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != calls+2 {
+		t.Fatalf("Expected %d records, got %d", calls+2, len(records))
+	}
+	seen := map[string]bool{}
+	for _, record := range records[1 : calls+1] {
+		if record.ProcessType != goutils.RequestProcess || record.ProcessId == "" || seen[record.ProcessId] {
+			t.Errorf("Expected a unique generated request id, got %+v", record)
+		}
+		seen[record.ProcessId] = true
+	}
+	if got := records[calls+1].ProcessId; got != "req-42" {
+		t.Errorf("Expected the supplied id to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,17 @@
+package goutils__test
+
+import (
+	"io"
+
+	goutils "github.com/biagioPiraino/go-utils"
+	"github.com/biagioPiraino/go-utils/goutilstest"
+)
+
+// the tests stop compiling if any of these contracts breaks
+var (
+	_ io.Closer          = (*goutils.Blogger)(nil)
+	_ io.Closer          = (*goutilstest.Capture)(nil)
+	_ goutilstest.Routed = (*goutils.Blogger)(nil)
+	_ goutilstest.Routed = (*goutilstest.Capture)(nil)
+	_ goutils.LogValuer  = loggedOrder{}
+)
@@ -0,0 +1,124 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper to list the names of the entries of dir
+func entries(t *testing.T, dir string) []string {
+	found, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Could not read %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range found {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// Test 1: Validate Bad Directory
+// A directory that cannot be created is reported without leaving files behind.
+func TestValidateLoggerBadDirectory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	blocker := filepath.Join(tempDir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("Failed to create blocking file: %v", err)
+	}
+
+	err = goutils.ValidateLogger(filepath.Join(blocker, "logs"), logsName, errorsName)
+	if !errors.Is(err, goutils.ErrLogDirCreate) {
+		t.Fatalf("Expected ErrLogDirCreate, got %v", err)
+	}
+	if names := entries(t, tempDir); len(names) != 1 || names[0] != "blocker" {
+		t.Errorf("Expected nothing but the blocker to be left, got %v", names)
+	}
+}
+
+// Test 2: Validate Without Creating
+// A valid configuration passes without creating the directory or the files.
+func TestValidateLoggerDryRun(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logDir := filepath.Join(tempDir, "nested", "logs")
+	if err := goutils.ValidateLogger(logDir, logsName, errorsName); err != nil {
+		t.Fatalf("Expected a valid configuration, got %v", err)
+	}
+	if names := entries(t, tempDir); len(names) != 0 {
+		t.Errorf("Expected the temp dir to stay empty, got %v", names)
+	}
+
+	// the same checks on the options as NewLogger
+	if err := goutils.ValidateLogger(tempDir, logsName, errorsName, goutils.WithDelimiter('"')); !errors.Is(err, goutils.ErrInvalidDelimiter) {
+		t.Errorf("Expected ErrInvalidDelimiter, got %v", err)
+	}
+}
+
+// Test 3: Validate Unwritable File
+// An existing path that cannot be opened as the log file is reported with ErrLogFileOpen.
+func TestValidateLoggerFileError(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	_, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if err := os.Mkdir(expectedErrPath, 0755); err != nil {
+		t.Fatalf("Failed to create blocking directory: %v", err)
+	}
+
+	if err := goutils.ValidateLogger(tempDir, logsName, errorsName); !errors.Is(err, goutils.ErrLogFileOpen) {
+		t.Errorf("Expected ErrLogFileOpen, got %v", err)
+	}
+	if names := entries(t, tempDir); len(names) != 1 {
+		t.Errorf("Expected no log file to be created, got %v", names)
+	}
+}
+
+// Test 4: Validate Mirror Directories
+// A mirror directory that cannot be created or holds an unwritable file fails validation.
+func TestValidateLoggerMirrorDirectory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logDir := filepath.Join(tempDir, "logs")
+	blocker := filepath.Join(tempDir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("Failed to create blocking file: %v", err)
+	}
+	err = goutils.ValidateLogger(logDir, logsName, errorsName, goutils.WithMirrorDirectory(filepath.Join(blocker, "mirror")))
+	if !errors.Is(err, goutils.ErrLogDirCreate) {
+		t.Errorf("Expected ErrLogDirCreate for the mirror, got %v", err)
+	}
+
+	mirrorDir := filepath.Join(tempDir, "mirror")
+	_, expectedErrPath := getExpectedFilenames(mirrorDir, logsName, errorsName)
+	if err := os.MkdirAll(expectedErrPath, 0755); err != nil {
+		t.Fatalf("Failed to create blocking directory: %v", err)
+	}
+	err = goutils.ValidateLogger(logDir, logsName, errorsName, goutils.WithMirrorDirectory(mirrorDir))
+	if !errors.Is(err, goutils.ErrLogFileOpen) {
+		t.Errorf("Expected ErrLogFileOpen for the mirror, got %v", err)
+	}
+	if names := entries(t, tempDir); len(names) != 2 {
+		t.Errorf("Expected the primary directory not to be created, got %v", names)
+	}
+}
@@ -0,0 +1,68 @@
+package goutils__test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Debug Dump
+// While enabled Debug and Trace events reach the dump even below the minimum severity, disabling removes it.
+func TestDebugDump(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithMinSeverity(goutils.Notice))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	dumpPath := filepath.Join(tempDir, "incident.csv")
+	if err := logger.EnableDebugDump(dumpPath); err != nil {
+		t.Fatalf("EnableDebugDump failed: %v", err)
+	}
+	if !logger.IsEnabled(goutils.Trace) {
+		t.Error("Expected Trace enabled while the dump is")
+	}
+	logger.Log(goutils.Trace, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Verbose detail"})
+	logger.Clone().Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Clone detail"})
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Regular notice"})
+
+	if findLine(t, dumpPath, "Verbose detail") == "" || findLine(t, dumpPath, "Clone detail") == "" {
+		t.Errorf("Expected the Trace and Debug events in the dump:\n%s", readFile(t, dumpPath))
+	}
+	if findLine(t, dumpPath, "Regular notice") != "" {
+		t.Error("Expected Notice events kept out of the dump")
+	}
+
+	if err := logger.DisableDebugDump(true); err != nil {
+		t.Fatalf("DisableDebugDump failed: %v", err)
+	}
+	if _, err := os.Stat(dumpPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the dump removed, got %v", err)
+	}
+	if logger.IsEnabled(goutils.Trace) {
+		t.Error("Expected Trace disabled again with the dump")
+	}
+	logger.Log(goutils.Trace, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After the incident"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logsPath, _ := logger.Paths()
+	if findLine(t, logsPath, "Regular notice") == "" {
+		t.Error("Expected the Notice event in the log file")
+	}
+	for _, event := range []string{"Verbose detail", "Clone detail", "After the incident"} {
+		if findLine(t, logsPath, event) != "" {
+			t.Errorf("Expected %q kept out of the log file below the minimum severity", event)
+		}
+	}
+	if _, err := os.Stat(dumpPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no dump recreated after disabling, got %v", err)
+	}
+}
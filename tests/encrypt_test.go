@@ -0,0 +1,145 @@
+package goutils__test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+var encryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+// Helper to create a logger writing encrypted lines
+func newEncryptedLogger(t *testing.T) *goutils.Blogger {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithEncryption(encryptionKey))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	return logger
+}
+
+// Test 1: Encryption Round Trip
+// Encrypted files hide the events on disk and decrypt back to the CSV lines.
+func TestEncryptionRoundTrip(t *testing.T) {
+	logger := newEncryptedLogger(t)
+
+	secret := "Card number 4242"
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "7", Event: secret})
+
+	raw, err := os.ReadFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if strings.Contains(string(raw), secret) || strings.Contains(string(raw), "NOTICE") {
+		t.Fatalf("Log file contains clear text. Got:\n%s", raw)
+	}
+
+	var out bytes.Buffer
+	if err := goutils.DecryptLogFile(logger.LogsFile.Name(), encryptionKey, &out); err != nil {
+		t.Fatalf("DecryptLogFile failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected init line and event, got %d lines:\n%s", len(lines), out.String())
+	}
+	if !strings.HasPrefix(lines[0], "TRACE,") {
+		t.Errorf("Expected decrypted init line, got %s", lines[0])
+	}
+	parts := strings.Split(lines[1], ",")
+	if parts[0] != "NOTICE" || parts[3] != "7" || parts[4] != secret {
+		t.Errorf("Unexpected decrypted line: %s", lines[1])
+	}
+}
+
+// Test 2: Wrong Key
+// Decrypting with a different key fails instead of returning garbage.
+func TestDecryptWrongKey(t *testing.T) {
+	logger := newEncryptedLogger(t)
+
+	wrongKey := []byte("fedcba9876543210fedcba9876543210")
+	var out bytes.Buffer
+	if err := goutils.DecryptLogFile(logger.LogsFile.Name(), wrongKey, &out); err == nil {
+		t.Fatal("Expected an error decrypting with the wrong key")
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected no output with the wrong key, got:\n%s", out.String())
+	}
+}
+
+// Test 3: Invalid Key
+// Keys that are not a valid AES size are rejected at construction.
+func TestEncryptionInvalidKey(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	if _, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithEncryption([]byte("short"))); err == nil {
+		t.Fatal("Expected an error for an invalid key size")
+	}
+}
+
+// Reader failing every read, standing in for an exhausted entropy source
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errEntropy
+}
+
+var errEntropy = errors.New("entropy source unavailable")
+
+// Test 4: Encryption Failure
+// A line that cannot be encrypted is reported to OnError and never written in clear.
+func TestEncryptionFailure(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithEncryption(encryptionKey),
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	// nonces are drawn from crypto/rand.Reader
+	reader := rand.Reader
+	rand.Reader = failingReader{}
+	secret := "Card number 4242"
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "7", Event: secret})
+	rand.Reader = reader
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var writeErr *goutils.WriteError
+	if len(reported) != 1 || !errors.As(reported[0], &writeErr) || !errors.Is(reported[0], errEntropy) {
+		t.Fatalf("Expected one WriteError wrapping the entropy failure, got %v", reported)
+	}
+	if writeErr.EventSeverity != goutils.Critical || writeErr.Event == nil || writeErr.Event.Event != secret {
+		t.Errorf("Expected the failed event in the WriteError, got %+v", writeErr)
+	}
+	if writeErr.Path != logger.ErrorsFile.Name() {
+		t.Errorf("Expected the destination %s, got %s", logger.ErrorsFile.Name(), writeErr.Path)
+	}
+	raw, err := os.ReadFile(logger.ErrorsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read error file: %v", err)
+	}
+	if strings.Contains(string(raw), secret) {
+		t.Errorf("Unencrypted line written. Got:\n%s", raw)
+	}
+}
@@ -0,0 +1,95 @@
+package goutils__test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Environment Configuration
+// Directory, names and level are all taken from the environment.
+func TestNewLoggerFromEnv(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	t.Setenv(goutils.EnvLogDir, tempDir)
+	t.Setenv(goutils.EnvLogName, "env_logs")
+	t.Setenv(goutils.EnvLogErrorName, "env_errors")
+	t.Setenv(goutils.EnvLogLevel, "notice")
+
+	logger, err := goutils.NewLoggerFromEnv()
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, "env_logs", "env_errors")
+	fileInfo(t, logger, expectedLogPath)
+	fileInfo(t, logger, expectedErrPath)
+
+	if !logger.IsEnabled(goutils.Notice) || logger.IsEnabled(goutils.Debug) {
+		t.Error("Expected Notice to be the minimum severity")
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Filtered debug"})
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Kept notice"})
+
+	content := readFile(t, expectedLogPath)
+	if strings.Contains(content, "Filtered debug") || !strings.Contains(content, "Kept notice") {
+		t.Errorf("Unexpected content with LOG_LEVEL=notice:\n%s", content)
+	}
+}
+
+// Test 2: Environment Defaults
+// Unset variables fall back to the documented defaults in the working directory.
+func TestNewLoggerFromEnvDefaults(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+	t.Chdir(tempDir)
+
+	for _, key := range []string{goutils.EnvLogDir, goutils.EnvLogName, goutils.EnvLogErrorName, goutils.EnvLogLevel} {
+		t.Setenv(key, "")
+	}
+
+	logger, err := goutils.NewLoggerFromEnv()
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+
+	expectedLogPath, expectedErrPath := getExpectedFilenames(
+		filepath.Join(tempDir, goutils.DefaultLogDir), goutils.DefaultLogName, goutils.DefaultLogName+"_errors")
+	for _, path := range []string{expectedLogPath, expectedErrPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected default file %s: %v", path, err)
+		}
+	}
+	if !logger.IsEnabled(goutils.Trace) {
+		t.Error("Expected every severity to be enabled by default")
+	}
+}
+
+// Test 3: Invalid Level
+// An unknown LOG_LEVEL is rejected instead of silently ignored.
+func TestNewLoggerFromEnvInvalidLevel(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	t.Setenv(goutils.EnvLogDir, tempDir)
+	t.Setenv(goutils.EnvLogLevel, "verbose")
+
+	if _, err := goutils.NewLoggerFromEnv(); err == nil || !strings.Contains(err.Error(), goutils.EnvLogLevel) {
+		t.Fatalf("Expected an invalid LOG_LEVEL error, got %v", err)
+	}
+}
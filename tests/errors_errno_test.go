@@ -0,0 +1,69 @@
+//go:build !plan9
+
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Write Error Classification
+// A full disk is reported as a fatal Critical WriteError, EAGAIN as a Notice unless overridden.
+func TestWriteErrorClassification(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }),
+		goutils.WithSeverityRoute(goutils.Alert, failingWriter{syscall.ENOSPC}),
+		goutils.WithSeverityRoute(goutils.Notice, failingWriter{syscall.EAGAIN}),
+		goutils.WithSeverityRoute(goutils.Debug, failingWriter{syscall.EPIPE}),
+		goutils.WithErrorSeverities(map[error]goutils.Severity{syscall.EPIPE: goutils.Notice}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	for _, severity := range []goutils.Severity{goutils.Alert, goutils.Notice, goutils.Debug} {
+		logger.Log(severity, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Lost"})
+	}
+	if len(reported) != 3 {
+		t.Fatalf("Expected three reported errors, got %v", reported)
+	}
+
+	expected := []struct {
+		cause    error
+		severity goutils.Severity
+		fatal    bool
+	}{
+		{syscall.ENOSPC, goutils.Critical, true},
+		{syscall.EAGAIN, goutils.Notice, false},
+		{syscall.EPIPE, goutils.Notice, false},
+	}
+	for i, want := range expected {
+		var writeErr *goutils.WriteError
+		if !errors.As(reported[i], &writeErr) {
+			t.Fatalf("Expected a *WriteError, got %T: %v", reported[i], reported[i])
+		}
+		if writeErr.Op != "write" || !errors.Is(writeErr, want.cause) {
+			t.Errorf("Expected a write error caused by %v, got %v", want.cause, writeErr)
+		}
+		if writeErr.Severity != want.severity || writeErr.Fatal() != want.fatal {
+			t.Errorf("Expected %v classified %s (fatal %v), got %s (fatal %v)",
+				want.cause, logger.SeverityName(want.severity), want.fatal,
+				logger.SeverityName(writeErr.Severity), writeErr.Fatal())
+		}
+	}
+	if !strings.Contains(reported[0].Error(), "error while writing to") {
+		t.Errorf("Expected the message to name the operation, got %q", reported[0])
+	}
+}
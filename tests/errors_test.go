@@ -0,0 +1,242 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Directory Creation Failure
+// A directory that cannot be created is reported with its path and ErrLogDirCreate.
+func TestNewLoggerDirError(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	// a regular file in the way makes the directory impossible to create
+	blocker := filepath.Join(tempDir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("Failed to create blocking file: %v", err)
+	}
+	logDir := filepath.Join(blocker, "logs")
+
+	_, err = goutils.NewLogger(logDir, logsName, errorsName)
+	if !errors.Is(err, goutils.ErrLogDirCreate) {
+		t.Fatalf("Expected ErrLogDirCreate, got %v", err)
+	}
+	if errors.Is(err, goutils.ErrLogFileOpen) {
+		t.Error("Did not expect ErrLogFileOpen for a directory failure")
+	}
+	if !strings.Contains(err.Error(), logDir) {
+		t.Errorf("Expected error to mention %s, got %v", logDir, err)
+	}
+}
+
+// Test 2: File Open Failure
+// A log file that cannot be opened is reported with its path and ErrLogFileOpen.
+func TestNewLoggerFileError(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	// a directory at the error file path cannot be opened for writing
+	_, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if err := os.Mkdir(expectedErrPath, 0755); err != nil {
+		t.Fatalf("Failed to create blocking directory: %v", err)
+	}
+
+	_, err = goutils.NewLogger(tempDir, logsName, errorsName)
+	if !errors.Is(err, goutils.ErrLogFileOpen) {
+		t.Fatalf("Expected ErrLogFileOpen, got %v", err)
+	}
+	if !strings.Contains(err.Error(), expectedErrPath) {
+		t.Errorf("Expected error to mention %s, got %v", expectedErrPath, err)
+	}
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) {
+		t.Errorf("Expected the underlying *os.PathError to be preserved, got %T", errors.Unwrap(err))
+	}
+}
+
+// writer failing every write with err
+type failingWriter struct {
+	err error
+}
+
+func (f failingWriter) Write(p []byte) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: "/var/log/app", Err: f.err}
+}
+
+// Test 3: Closed Logger
+// Closing twice, logging, flushing and rotating after Close all match ErrLoggerClosed.
+func TestErrLoggerClosed(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("First Close failed: %v", err)
+	}
+
+	if err := logger.Close(); !errors.Is(err, goutils.ErrLoggerClosed) {
+		t.Errorf("Expected ErrLoggerClosed from a second Close, got %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Too late"})
+	if len(reported) != 1 || !errors.Is(reported[0], goutils.ErrLoggerClosed) || !errors.Is(reported[0], goutils.ErrWriteFailed) {
+		t.Errorf("Expected a write failure caused by ErrLoggerClosed, got %v", reported)
+	}
+	if err := logger.Flush(); !errors.Is(err, goutils.ErrLoggerClosed) || !errors.Is(err, goutils.ErrWriteFailed) {
+		t.Errorf("Expected Flush to fail with ErrLoggerClosed, got %v", err)
+	}
+	if err := logger.Rotate(); !errors.Is(err, goutils.ErrLoggerClosed) {
+		t.Errorf("Expected Rotate to fail with ErrLoggerClosed, got %v", err)
+	}
+}
+
+// Test 4: Write Failed
+// Lines a destination rejects are reported as ErrWriteFailed alongside their cause.
+func TestErrWriteFailed(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }),
+		goutils.WithSeverityRoute(goutils.Alert, failingWriter{syscall.EIO}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Lost"})
+	if len(reported) != 1 || !errors.Is(reported[0], goutils.ErrWriteFailed) || !errors.Is(reported[0], syscall.EIO) {
+		t.Errorf("Expected ErrWriteFailed caused by EIO, got %v", reported)
+	}
+	if errors.Is(reported[0], goutils.ErrLoggerClosed) {
+		t.Errorf("Did not expect ErrLoggerClosed for an open logger")
+	}
+}
+
+// Test 5: Failed Event
+// The WriteError of a lost line carries the event and its severity so it can be logged again.
+func TestWriteErrorEvent(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }),
+		goutils.WithSeverityRoute(goutils.Alert, failingWriter{syscall.EIO}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	event := goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "req-1", Event: "Payment declined"}
+	logger.Log(goutils.Alert, event)
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Written"})
+	if len(reported) != 1 {
+		t.Fatalf("Expected one reported error, got %v", reported)
+	}
+
+	var writeErr *goutils.WriteError
+	if !errors.As(reported[0], &writeErr) {
+		t.Fatalf("Expected a *WriteError, got %T: %v", reported[0], reported[0])
+	}
+	if writeErr.Event == nil || *writeErr.Event != event || writeErr.EventSeverity != goutils.Alert {
+		t.Fatalf("Expected the failed Alert event %+v, got %s %+v", event, logger.SeverityName(writeErr.EventSeverity), writeErr.Event)
+	}
+
+	// the event can be logged again as it was
+	logger.Log(goutils.Critical, *writeErr.Event)
+	records, err := goutils.ParseLogFile(logger.ErrorsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse the error file: %v", err)
+	}
+	last := records[len(records)-1]
+	if last.ProcessType != event.ProcessType || last.ProcessId != event.ProcessId || last.Event != event.Event {
+		t.Errorf("Expected the retried event in the error file, got %+v", last)
+	}
+}
+
+// Test 6: Retry From OnError
+// The OnError function can log the failed event again without deadlocking the logger.
+func TestRetryFromOnError(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var logger *goutils.Blogger
+	retried, mirrorErrors := 0, 0
+	logger, err = goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithSeverityRoute(goutils.Alert, failingWriter{syscall.EIO}),
+		goutils.WithWriteFunc(func(severity goutils.Severity, line []byte) error {
+			if severity == goutils.Notice {
+				return errors.New("mirror unavailable")
+			}
+			return nil
+		}),
+		goutils.WithOnError(func(err error) {
+			var writeErr *goutils.WriteError
+			if errors.As(err, &writeErr) && writeErr.Event != nil && writeErr.EventSeverity == goutils.Alert {
+				retried++
+				logger.Log(goutils.Critical, *writeErr.Event)
+				return
+			}
+			mirrorErrors++
+			logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: err.Error()})
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "req-1", Event: "Payment declined"})
+		logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Mirrored"})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Logging from OnError deadlocked")
+	}
+
+	if retried != 1 || mirrorErrors != 1 {
+		t.Fatalf("Expected one retried write and one mirror error, got %d and %d", retried, mirrorErrors)
+	}
+	records, err := goutils.ParseLogFile(logger.ErrorsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse the error file: %v", err)
+	}
+	if last := records[len(records)-1]; last.Severity != goutils.Critical || last.Event != "Payment declined" {
+		t.Errorf("Expected the retried event in the error file, got %+v", last)
+	}
+}
@@ -0,0 +1,47 @@
+//go:build windows
+
+package goutils__test
+
+import (
+	"os"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Event Log Levels
+// Severities map to Error, Warning and Info entries.
+func TestEventLogType(t *testing.T) {
+	expected := map[goutils.Severity]goutils.EventLogType{
+		goutils.Emergency: goutils.EventLogError,
+		goutils.Alert:     goutils.EventLogError,
+		goutils.Critical:  goutils.EventLogError,
+		goutils.Notice:    goutils.EventLogWarning,
+		goutils.Debug:     goutils.EventLogInfo,
+		goutils.Trace:     goutils.EventLogInfo,
+	}
+	for severity, level := range expected {
+		if got := severity.EventLogType(); got != level {
+			t.Errorf("Expected %s to map to %d, got %d", severity.ToString(), level, got)
+		}
+	}
+}
+
+// Test 2: Event Log Sink
+// Lines reach the Event Log besides the files, skipped where the source cannot be opened.
+func TestWithEventLog(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithEventLog("go-utils-test"))
+	if err != nil {
+		t.Skipf("Event Log not available: %v", err)
+	}
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Event log test"})
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Exit On Emergency
+// The exit function is called with the configured code once the Emergency line is on disk.
+func TestWithExitOnEmergency(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	exits := 0
+	code := -1
+	onDisk := ""
+	var errorsPath string
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithBuffering(4096),
+		goutils.WithReservoir(goutils.Emergency, 1, time.Hour),
+		goutils.WithExitOnEmergency(3),
+		goutils.WithExitFunc(func(c int) {
+			exits++
+			code = c
+			onDisk = readFile(t, errorsPath)
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+	_, errorsPath = logger.Paths()
+
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Not fatal"})
+	if exits != 0 {
+		t.Fatal("Expected no exit for an Alert event")
+	}
+
+	logger.Log(goutils.Emergency, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "System unusable"})
+	if exits != 1 || code != 3 {
+		t.Fatalf("Expected one exit with code 3, got %d exits with code %d", exits, code)
+	}
+	if !strings.Contains(onDisk, "EMERGENCY") || !strings.Contains(onDisk, "System unusable") {
+		t.Errorf("Expected the Emergency line on disk before exiting, got %q", onDisk)
+	}
+}
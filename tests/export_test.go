@@ -0,0 +1,58 @@
+package goutils__test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Export JSON Array
+// Matching records of several files are exported as one array that unmarshals into a slice.
+func TestExportJSONArray(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: `GET "/"`})
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "2", Event: "Ignored"})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "3", Event: "POST /pay"})
+	logsPath, errorsPath := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	opts := goutils.QueryOptions{ProcessTypes: []goutils.ProcessType{goutils.RequestProcess}}
+	if err := goutils.ExportJSONArray([]string{logsPath, errorsPath}, &out, opts); err != nil {
+		t.Fatalf("ExportJSONArray failed: %v", err)
+	}
+
+	var records []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &records); err != nil {
+		t.Fatalf("Export is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected the two request records, got %d: %s", len(records), out.String())
+	}
+	if records[0]["event"] != `GET "/"` || records[0]["severity"] != "NOTICE" || records[1]["process_id"] != "3" {
+		t.Errorf("Unexpected records: %v", records)
+	}
+
+	// nothing matching is still a well formed array
+	out.Reset()
+	if err := goutils.ExportJSONArray([]string{logsPath}, &out, goutils.QueryOptions{Contains: "missing"}); err != nil {
+		t.Fatalf("ExportJSONArray failed: %v", err)
+	}
+	if out.String() != "[]" {
+		t.Errorf("Expected an empty array, got %q", out.String())
+	}
+}
@@ -0,0 +1,40 @@
+package goutils__test
+
+import (
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Fingerprint
+// Events differing only in numbers and UUIDs share a fingerprint, different texts do not.
+func TestFingerprint(t *testing.T) {
+	first := goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "a",
+		Event: "request 6ba7b810-9dad-11d1-80b4-00c04fd430c8 failed for user 42 after 3 retries"}
+	second := goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "b",
+		Event: "request 1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed failed for user 7 after 10 retries"}
+	other := goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "a",
+		Event: "request 6ba7b810-9dad-11d1-80b4-00c04fd430c8 succeeded for user 42"}
+
+	if goutils.Fingerprint(first) != goutils.Fingerprint(second) {
+		t.Errorf("Expected events differing only in ids to share a fingerprint, normalized to %q and %q",
+			goutils.NormalizeEvent(first.Event), goutils.NormalizeEvent(second.Event))
+	}
+	if goutils.Fingerprint(first) == goutils.Fingerprint(other) {
+		t.Error("Expected different events to have different fingerprints")
+	}
+	if goutils.Fingerprint(first) != goutils.Fingerprint(first) || len(goutils.Fingerprint(first)) != 16 {
+		t.Errorf("Expected a stable 16 character fingerprint, got %q", goutils.Fingerprint(first))
+	}
+
+	// a custom normalizer, e.g. ignoring case
+	upper := goutils.LogEvent{Event: "Disk FULL"}
+	lower := goutils.LogEvent{Event: "disk full"}
+	if goutils.FingerprintWith(upper, strings.ToLower) != goutils.FingerprintWith(lower, strings.ToLower) {
+		t.Error("Expected the custom normalizer to be applied")
+	}
+	if goutils.FingerprintWith(upper, nil) == goutils.FingerprintWith(lower, nil) {
+		t.Error("Expected texts hashed as they are without a normalizer")
+	}
+}
@@ -0,0 +1,161 @@
+package goutils__test
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+	"github.com/biagioPiraino/go-utils/goutilstest"
+)
+
+// Fake testing.TB recording failures instead of failing the real test
+type recordingTB struct {
+	testing.TB
+	mu       sync.Mutex
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// Helper running AssertRouting against a recording TB
+func assertRoutingFailures(logger goutilstest.Routed) []string {
+	recorder := &recordingTB{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		goutilstest.AssertRouting(recorder, logger)
+	}()
+	<-done
+	return recorder.failures
+}
+
+// Logger reporting the severities of its two files swapped
+type misreportedLogger struct {
+	*goutilstest.Capture
+}
+
+func (m misreportedLogger) Files() []goutils.FileInfo {
+	files := m.Capture.Files()
+	files[0].Severities, files[1].Severities = files[1].Severities, files[0].Severities
+	return files
+}
+
+// Test 1: Capture Logger
+// Captured lines are split between the two buffers like the files would be.
+func TestCaptureLogger(t *testing.T) {
+	capture := goutilstest.CaptureLogger(t)
+
+	capture.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Captured critical"})
+	capture.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Captured debug"})
+
+	if errs := capture.Errors(); !strings.Contains(errs, "Captured critical") || strings.Contains(errs, "Captured debug") {
+		t.Errorf("Unexpected error buffer:\n%s", errs)
+	}
+	logs := capture.Logs()
+	if !strings.Contains(logs, "Captured debug") || strings.Contains(logs, "Captured critical") {
+		t.Errorf("Unexpected log buffer:\n%s", logs)
+	}
+	if !strings.Contains(logs, goutils.InitEvent) {
+		t.Errorf("Expected the init line in the log buffer:\n%s", logs)
+	}
+}
+
+// Test 2: Routing Assertions
+// AssertRouting passes for file and capture loggers and catches misrouting.
+func TestAssertRouting(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if failures := assertRoutingFailures(logger); len(failures) != 0 {
+		t.Errorf("Expected file logger routing to pass, got %v", failures)
+	}
+
+	capture := goutilstest.CaptureLogger(t)
+	if failures := assertRoutingFailures(capture); len(failures) != 0 {
+		t.Errorf("Expected capture logger routing to pass, got %v", failures)
+	}
+
+	// six severities missing from where they are expected, six leaking elsewhere
+	failures := assertRoutingFailures(misreportedLogger{goutilstest.CaptureLogger(t)})
+	if len(failures) != 12 {
+		t.Errorf("Expected 12 routing failures, got %d: %v", len(failures), failures)
+	}
+}
+
+// Test 3: Capture After Rollover
+// Logs and Errors return the active destinations, not whichever buffer comes first.
+func TestCaptureActiveDestination(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	capture := goutilstest.CaptureLogger(t, goutils.WithRotationInterval(goutils.Hourly), goutils.WithClock(clock))
+
+	capture.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Before the hour"})
+	mu.Lock()
+	now = now.Add(time.Hour)
+	mu.Unlock()
+	capture.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After the hour"})
+	capture.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Critical after the hour"})
+
+	for range 10 {
+		if logs := capture.Logs(); !strings.Contains(logs, "After the hour") || strings.Contains(logs, "Before the hour") {
+			t.Fatalf("Expected the buffer of the new hour, got:\n%s", logs)
+		}
+		if errs := capture.Errors(); !strings.Contains(errs, "Critical after the hour") {
+			t.Fatalf("Expected the error buffer of the new hour, got:\n%s", errs)
+		}
+	}
+
+	live := goutilstest.CaptureLogger(t, goutils.WithLiveCompression())
+	if err := live.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if live.Logs() == "" {
+		t.Error("Expected the live compressed buffer to be found")
+	}
+}
+
+// Test 4: Routing Assertions With Minimum Severity
+// Severities filtered out by WithMinSeverity are neither logged nor expected by AssertRouting.
+func TestAssertRoutingMinSeverity(t *testing.T) {
+	capture := goutilstest.CaptureLogger(t, goutils.WithMinSeverity(goutils.Notice))
+	if failures := assertRoutingFailures(capture); len(failures) != 0 {
+		t.Errorf("Expected filtered capture logger routing to pass, got %v", failures)
+	}
+	if logs := capture.Logs(); strings.Contains(logs, "DEBUG") || strings.Contains(logs, "TRACE") {
+		t.Errorf("Expected no marker below the minimum severity, got:\n%s", logs)
+	}
+
+	// four severities missing from where they are expected, four leaking elsewhere
+	failures := assertRoutingFailures(misreportedLogger{goutilstest.CaptureLogger(t, goutils.WithMinSeverity(goutils.Notice))})
+	if len(failures) != 8 {
+		t.Errorf("Expected 8 routing failures, got %d: %v", len(failures), failures)
+	}
+}
@@ -0,0 +1,71 @@
+package goutils__test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Grep Event Field
+// Only records whose event matches are returned, across compressed backups, whatever the other columns hold.
+func TestGrep(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithCompressionAlgo(goutils.Gzip))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "timeout, retrying"})
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "timeout-worker", Event: "started"})
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "2", Event: "Timeout calling payments"})
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "3", Event: "no timeout here"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(tempDir, "*.csv*"))
+	if err != nil {
+		t.Fatalf("Could not list the files: %v", err)
+	}
+	compressed := 0
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".gz") {
+			compressed++
+		}
+	}
+	if compressed == 0 {
+		t.Fatalf("Expected compressed backups among %v", paths)
+	}
+
+	var matched []string
+	err = goutils.Grep(paths, goutils.EventColumn, regexp.MustCompile(`(?i)^timeout`), func(record goutils.LogRecord) {
+		matched = append(matched, record.Event)
+	})
+	if err != nil {
+		t.Fatalf("Grep failed: %v", err)
+	}
+	if len(matched) != 2 {
+		t.Fatalf("Expected two matching events, got %q", matched)
+	}
+	for _, event := range matched {
+		if event != "timeout, retrying" && event != "Timeout calling payments" {
+			t.Errorf("Unexpected match %q", event)
+		}
+	}
+
+	missing := filepath.Join(tempDir, "missing.csv")
+	if err := goutils.Grep([]string{missing}, goutils.EventColumn, regexp.MustCompile("."), func(goutils.LogRecord) {}); err == nil || !strings.Contains(err.Error(), missing) {
+		t.Errorf("Expected an error naming the missing file, got %v", err)
+	}
+}
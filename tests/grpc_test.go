@@ -0,0 +1,220 @@
+package goutils__test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Helper to start an in-memory health server wired with the logger interceptors
+func startHealthServer(t *testing.T, logger *goutils.Blogger) healthpb.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(logger.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(logger.StreamServerInterceptor()))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial in-memory server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// Helper to find the line containing the given text
+func findLine(t *testing.T, path string, text string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Could not read file at %s: %v", path, err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.Contains(line, text) {
+			return line
+		}
+	}
+	return ""
+}
+
+// Test 1: Unary Interceptor
+// Successful calls are logged at Notice and failed ones at Critical with the metadata request id.
+func TestUnaryServerInterceptor(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	client := startHealthServer(t, logger)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), goutils.RequestIDMetadataKey, "req-42")
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Health check failed: %v", err)
+	}
+	// unknown services are answered with NotFound
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "missing"}); err == nil {
+		t.Fatal("Expected health check of unknown service to fail")
+	}
+
+	line := findLine(t, logger.LogsFile.Name(), "/grpc.health.v1.Health/Check")
+	if line == "" {
+		t.Fatal("Could not find the successful call in the log file")
+	}
+	parts := strings.Split(line, ",")
+	if parts[0] != "NOTICE" || parts[2] != "Request" || parts[3] != "req-42" {
+		t.Errorf("Unexpected fields for successful call: %s", line)
+	}
+	if !strings.Contains(parts[4], "code=OK") || !strings.Contains(parts[4], "latency=") {
+		t.Errorf("Missing code or latency in event: %s", parts[4])
+	}
+
+	line = findLine(t, logger.ErrorsFile.Name(), "/grpc.health.v1.Health/Check")
+	if line == "" {
+		t.Fatal("Could not find the failed call in the error file")
+	}
+	if !strings.HasPrefix(line, "CRITICAL,") || !strings.Contains(line, "code=NotFound") {
+		t.Errorf("Unexpected fields for failed call: %s", line)
+	}
+}
+
+// Test 2: Stream Interceptor
+// Streams are logged once the handler returns, using the configured severities.
+func TestStreamServerInterceptor(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithRequestSeverities(goutils.Debug, goutils.Alert))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	client := startHealthServer(t, logger)
+
+	ctx, cancel := context.WithCancel(
+		metadata.AppendToOutgoingContext(context.Background(), goutils.RequestIDMetadataKey, "stream-7"))
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Failed to open watch stream: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Failed to receive from watch stream: %v", err)
+	}
+	// the watch handler only returns once the client goes away
+	cancel()
+
+	var line string
+	for i := 0; i < 100 && line == ""; i++ {
+		line = findLine(t, logger.ErrorsFile.Name(), "/grpc.health.v1.Health/Watch")
+		if line == "" {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if line == "" {
+		t.Fatal("Could not find the stream in the error file")
+	}
+	parts := strings.Split(line, ",")
+	if parts[0] != "ALERT" || parts[3] != "stream-7" || !strings.Contains(parts[4], "code=Canceled") {
+		t.Errorf("Unexpected fields for cancelled stream: %s", line)
+	}
+}
+
+// Test 3: Generated Request IDs
+// Calls without the metadata request id are logged with an id from the configured generator.
+func TestInterceptorIDGenerator(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	generated := 0
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithIDGenerator(func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		generated++
+		return fmt.Sprintf("gen-%d", generated)
+	}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	client := startHealthServer(t, logger)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatalf("Health check failed: %v", err)
+		}
+	}
+	for _, id := range []string{"gen-1", "gen-2"} {
+		if line := findLine(t, logger.LogsFile.Name(), ",Request,"+id+","); line == "" {
+			t.Errorf("Expected a call logged with generated id %s", id)
+		}
+	}
+}
+
+// Helper stream serving only the context of an incoming call
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// Test 4: Stream Context
+// The stream handler's context carries the request id for LogContext.
+func TestStreamServerInterceptorContext(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(goutils.RequestIDMetadataKey, "stream-9"))
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+	err = logger.StreamServerInterceptor()(nil, contextStream{ctx: ctx}, info, func(_ any, stream grpc.ServerStream) error {
+		return logger.LogContext(stream.Context(), goutils.Debug, goutils.LogEvent{Event: "Inside the stream"})
+	})
+	if err != nil {
+		t.Fatalf("Stream handler failed: %v", err)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if line := findLine(t, logger.LogsFile.Name(), "Inside the stream"); !strings.Contains(line, ",Request,stream-9,") {
+		t.Errorf("Expected the handler event to carry the request id, got %q", line)
+	}
+}
@@ -0,0 +1,97 @@
+package goutils__test
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Changing The Level While Logging
+// SetMinSeverity races with logging goroutines without a data race and takes effect at once.
+func TestSetMinSeverity(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: "1", Event: "Toggling"})
+			}
+		}()
+	}
+	for j := 0; j < 200; j++ {
+		if j%2 == 0 {
+			logger.SetMinSeverity(goutils.Notice)
+		} else {
+			logger.SetMinSeverity(goutils.Trace)
+		}
+	}
+	wg.Wait()
+
+	logger.SetMinSeverity(goutils.Notice)
+	if severity := logger.MinSeverity(); severity != goutils.Notice {
+		t.Errorf("Expected Notice as the minimum severity, got %s", severity.ToString())
+	}
+	if logger.IsEnabled(goutils.Debug) {
+		t.Error("Expected Debug disabled after SetMinSeverity(Notice)")
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+// Test 2: Shared Level
+// Loggers built with the same level follow a change made through any of them.
+func TestWithSharedLevel(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var level atomic.Int32
+	level.Store(int32(goutils.Notice))
+	first, err := goutils.NewLogger(tempDir, "first", "first_errors", goutils.WithSharedLevel(&level))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	second, err := goutils.NewLogger(tempDir, "second", "second_errors", goutils.WithSharedLevel(&level))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	clone := first.Clone()
+	if second.IsEnabled(goutils.Debug) {
+		t.Error("Expected the stored Notice level to apply")
+	}
+
+	first.SetMinSeverity(goutils.Trace)
+	for name, logger := range map[string]*goutils.Blogger{"second": second, "clone": clone} {
+		if logger.MinSeverity() != goutils.Trace {
+			t.Errorf("Expected the %s logger to follow the shared level, got %s", name, logger.MinSeverity().ToString())
+		}
+	}
+
+	own := first.Clone(goutils.WithMinSeverity(goutils.Critical))
+	level.Store(int32(goutils.Debug))
+	if own.MinSeverity() != goutils.Critical {
+		t.Errorf("Expected a clone given WithMinSeverity to keep its own level, got %s", own.MinSeverity().ToString())
+	}
+	for _, logger := range []*goutils.Blogger{first, second} {
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+	}
+}
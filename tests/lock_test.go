@@ -0,0 +1,45 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Exclusive Lock
+// A second logger on the same files fails to open until the first one, rotated or not, is closed.
+func TestWithExclusiveLock(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	first, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithExclusiveLock())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	_, err = goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithExclusiveLock())
+	if !errors.Is(err, goutils.ErrFileLocked) || !errors.Is(err, goutils.ErrLogFileOpen) {
+		t.Fatalf("Expected ErrFileLocked opening locked files, got %v", err)
+	}
+
+	if err := first.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	_, err = goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithExclusiveLock())
+	if !errors.Is(err, goutils.ErrFileLocked) {
+		t.Fatalf("Expected the rotated files to be locked too, got %v", err)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	second, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithExclusiveLock())
+	if err != nil {
+		t.Fatalf("Expected the files to be free after Close, got %v", err)
+	}
+	second.Close()
+}
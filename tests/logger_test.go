@@ -1,15 +1,22 @@
 package goutils__test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	goutils "github.com/biagioPiraino/go-utils"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -27,10 +34,8 @@ func cleanup(dir string) {
 
 // Helper to generate expected filenames based on the logic in logger.go
 func getExpectedFilenames(dir, logName, errName string) (string, string) {
-	date := time.Now().UTC().Format("2006-01-02")
-	logFile := filepath.Join(dir, fmt.Sprintf("%s-%s.csv", date, logName))
-	errFile := filepath.Join(dir, fmt.Sprintf("%s-%s.csv", date, errName))
-	return logFile, errFile
+	now := time.Now()
+	return goutils.ExpectedFilename(dir, logName, now), goutils.ExpectedFilename(dir, errName, now)
 }
 
 // Test 1: Verify Enum String Conversions
@@ -107,7 +112,7 @@ func TestLoggerIntegration(t *testing.T) {
 	}
 
 	// 7. Verify Log File Content
-	// Note: Initialization logs a TRACE event, so we expect that + our DEBUG message
+	// Note: Initialization logs a goutils.InitEvent line, so we expect that + our DEBUG message
 	contentLog, err := os.ReadFile(expectedLogPath)
 	if err != nil {
 		t.Fatalf("Could not read log file at %s: %v", expectedLogPath, err)
@@ -219,3 +224,591 @@ func TestConcurrency(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// Test 5: Context Aware Logging
+// A cancelled context makes LogContext return promptly without writing the event.
+func TestLogContext(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatal("Logger not initialised correclty")
+	}
+
+	keptMsg := "Live context event"
+	if err := logger.LogContext(context.Background(), goutils.Debug, goutils.LogEvent{
+		ProcessType: goutils.RequestProcess,
+		ProcessId:   "1",
+		Event:       keptMsg,
+	}); err != nil {
+		t.Fatalf("Expected no error with a live context, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	droppedMsg := "Cancelled context event"
+	start := time.Now()
+	err = logger.LogContext(ctx, goutils.Debug, goutils.LogEvent{
+		ProcessType: goutils.RequestProcess,
+		ProcessId:   "2",
+		Event:       droppedMsg,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("LogContext did not return promptly, took %s", elapsed)
+	}
+
+	content, err := os.ReadFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if !strings.Contains(string(content), keptMsg) {
+		t.Errorf("Log file missing event logged with a live context. Got:\n%s", content)
+	}
+	if strings.Contains(string(content), droppedMsg) {
+		t.Error("Log file contains an event logged with a cancelled context.")
+	}
+}
+
+// Test 6: Lifecycle Events
+// Startup and shutdown events are both logged, the latter with a plausible uptime.
+func TestLifecycleLogs(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithLifecycleLogs())
+	if err != nil {
+		t.Fatal("Logger not initialised correclty")
+	}
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	wait := 20 * time.Millisecond
+	time.Sleep(wait)
+	logger.Close()
+
+	content, err := os.ReadFile(expectedLogPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected startup and shutdown lines, got %d:\n%s", len(lines), content)
+	}
+	if !strings.Contains(lines[0], goutils.InitEvent) {
+		t.Errorf("Expected startup line first, got %s", lines[0])
+	}
+
+	parts := strings.Split(lines[1], ",")
+	if parts[0] != "TRACE" || parts[2] != "Operating System" || parts[3] != strconv.Itoa(os.Getpid()) {
+		t.Errorf("Unexpected shutdown fields: %s", lines[1])
+	}
+	const prefix = goutils.CloseEventPrefix
+	if !strings.HasPrefix(parts[4], prefix) {
+		t.Fatalf("Expected shutdown event, got %s", parts[4])
+	}
+	uptime, err := time.ParseDuration(strings.TrimPrefix(parts[4], prefix))
+	if err != nil {
+		t.Fatalf("Could not parse uptime: %v", err)
+	}
+	if uptime < wait || uptime > wait+5*time.Second {
+		t.Errorf("Implausible uptime %s", uptime)
+	}
+}
+
+// Test 7: Timestamp Ordering
+// Lines written by many goroutines appear in non-decreasing timestamp order (run with -race).
+func TestTimestampOrdering(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatal("Logger not initialised correclty")
+	}
+
+	var wg sync.WaitGroup
+	routines := 50
+	wg.Add(routines)
+	for i := 0; i < routines; i++ {
+		go func(val int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				logger.Log(goutils.Debug, goutils.LogEvent{
+					ProcessType: goutils.GoRoutineProcess,
+					ProcessId:   fmt.Sprintf("%d-%d", val, j),
+					Event:       "Ordering test",
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	records, err := goutils.ParseLogFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != routines*20+1 {
+		t.Fatalf("Expected %d records, got %d", routines*20+1, len(records))
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].Timestamp.Before(records[i-1].Timestamp) {
+			t.Fatalf("Line %d has timestamp %s before previous %s", i+1, records[i].Timestamp, records[i-1].Timestamp)
+		}
+	}
+}
+
+// Test 8: Nil Logger
+// Checks every public method degrades on a nil logger instead of panicking
+func TestNilLogger(t *testing.T) {
+	var stderr bytes.Buffer
+	log.SetOutput(&stderr)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	var logger *goutils.Blogger
+	event := goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Nil logger event"}
+
+	logger.Log(goutils.Critical, event)
+	if !strings.Contains(stderr.String(), "CRITICAL") || !strings.Contains(stderr.String(), "Nil logger event") {
+		t.Errorf("Expected the event on stderr, got %q", stderr.String())
+	}
+
+	if err := logger.LogContext(context.Background(), goutils.Notice, event); err != nil {
+		t.Errorf("Expected no error from LogContext, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := logger.LogContext(ctx, goutils.Notice, event); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from LogContext, got %v", err)
+	}
+	if !logger.IsEnabled(goutils.Trace) {
+		t.Error("Expected every severity to be enabled")
+	}
+	if name := logger.SeverityName(goutils.Alert); name != "ALERT" {
+		t.Errorf("Expected default severity name, got %q", name)
+	}
+	if name := logger.ProcessTypeName(goutils.RequestProcess); name != "Request" {
+		t.Errorf("Expected default process type name, got %q", name)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Errorf("Expected no error from Flush, got %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Expected no error from Sync, got %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Errorf("Expected no error from Rotate, got %v", err)
+	}
+	if err := logger.Reset(); err != nil {
+		t.Errorf("Expected no error from Reset, got %v", err)
+	}
+	if files := logger.Files(); files != nil {
+		t.Errorf("Expected no files, got %v", files)
+	}
+	if logsPath, errorsPath := logger.Paths(); logsPath != "" || errorsPath != "" {
+		t.Errorf("Expected no paths, got %q and %q", logsPath, errorsPath)
+	}
+	if lines := logger.RecentLogs(); lines != nil {
+		t.Errorf("Expected no recent lines, got %v", lines)
+	}
+	if stats := logger.DropStats(); stats != (goutils.DropStats{}) {
+		t.Errorf("Expected zero drop stats, got %+v", stats)
+	}
+	if stats := logger.Stats(); stats != nil {
+		t.Errorf("Expected no stats, got %v", stats)
+	}
+	if window := logger.WindowStats(time.Minute); window != nil {
+		t.Errorf("Expected no window stats, got %v", window)
+	}
+	if logger.HasSeparateErrorFile() {
+		t.Error("Expected no separate error file")
+	}
+
+	resp, err := logger.UnaryServerInterceptor()(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "/test.Service/Unary"},
+		func(ctx context.Context, req any) (any, error) { return req, nil })
+	if resp != "request" || err != nil {
+		t.Errorf("Expected the unary handler to be called, got %v, %v", resp, err)
+	}
+	handlerErr := errors.New("stream ended")
+	err = logger.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"},
+		func(srv any, ss grpc.ServerStream) error { return handlerErr })
+	if err != handlerErr {
+		t.Errorf("Expected the stream handler error, got %v", err)
+	}
+
+	logger.ResetStats()
+	logger.Close()
+}
+
+// Test 9: Severity Comparison
+// Checks AtLeast and MoreSevereThan follow the priority ordering, including at the Notice/Debug/Trace boundary
+func TestSeverityComparison(t *testing.T) {
+	ordered := []goutils.Severity{goutils.Trace, goutils.Debug, goutils.Notice, goutils.Critical, goutils.Alert, goutils.Emergency}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got := a.AtLeast(b); got != (i >= j) {
+				t.Errorf("%s.AtLeast(%s) = %v", a.ToString(), b.ToString(), got)
+			}
+			if got := a.MoreSevereThan(b); got != (i > j) {
+				t.Errorf("%s.MoreSevereThan(%s) = %v", a.ToString(), b.ToString(), got)
+			}
+		}
+	}
+
+	var level goutils.Level = goutils.Debug
+	if !level.AtLeast(goutils.Debug) || level.AtLeast(goutils.Notice) || !level.MoreSevereThan(goutils.Trace) {
+		t.Error("Expected Level to compare the same as Severity")
+	}
+	if goutils.Severity(42).AtLeast(goutils.Trace) {
+		t.Error("Expected unknown severities to rank below Trace")
+	}
+
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithMinSeverity(goutils.Debug))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+	if !logger.IsEnabled(goutils.Notice) || !logger.IsEnabled(goutils.Debug) || logger.IsEnabled(goutils.Trace) {
+		t.Error("Expected Notice and Debug enabled and Trace disabled")
+	}
+}
+
+// Test 10: Close Stops Background Goroutines
+// No goroutine started by the logger is left running once Close returns.
+func TestCloseStopsGoroutines(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	before := runtime.NumGoroutine()
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithBuffering(4096),
+		goutils.WithFlushInterval(10*time.Millisecond),
+		goutils.WithReservoir(goutils.Trace, 2, time.Hour),
+		goutils.WithRotationHook(func(string, string) { time.Sleep(50 * time.Millisecond) }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// goroutines that have returned may take a moment to be accounted for
+	after := runtime.NumGoroutine()
+	for deadline := time.Now().Add(time.Second); after > before && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		after = runtime.NumGoroutine()
+	}
+	if after > before {
+		t.Errorf("Expected at most %d goroutines after Close, got %d", before, after)
+	}
+}
+
+// Test 11: Close Timeout
+// A background goroutine outliving the close timeout is reported while the files are still closed.
+func TestCloseTimeout(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	release := make(chan struct{})
+	defer close(release)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithCloseTimeout(20*time.Millisecond),
+		goutils.WithRotationHook(func(string, string) { <-release }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	if err := logger.Close(); !errors.Is(err, goutils.ErrCloseTimeout) {
+		t.Fatalf("Expected ErrCloseTimeout, got %v", err)
+	}
+	if _, err := logger.LogsFile.Write([]byte("late")); err == nil {
+		t.Error("Expected the logs file to be closed after the timeout")
+	}
+}
+
+// Test 12: Log At A Given Time
+// Backfilled events carry the provided timestamp instead of the current time.
+func TestLogAt(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	past := time.Date(2019, time.July, 4, 9, 30, 15, 0, time.FixedZone("UTC-5", -5*60*60))
+	logger.LogAt(past, goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Backfilled"})
+	logger.Close()
+
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != 2 || !records[1].Timestamp.Equal(past) {
+		t.Fatalf("Expected the backfilled event at %s, got %+v", past, records)
+	}
+	if line := findLine(t, logsPath, "Backfilled"); !strings.Contains(line, ",2019-07-04T14:30:15Z,") {
+		t.Errorf("Expected the timestamp written in UTC, got %q", line)
+	}
+}
+
+// Test 13: Init Lines
+// Each logger opening a file writes exactly one init line, counted without matching its text.
+func TestCountInitLines(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	for i := 0; i < 2; i++ {
+		logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+		if err != nil {
+			t.Fatalf("Logger was not initialised: %v", err)
+		}
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Restarted"})
+		logger.Close()
+	}
+
+	logsPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if inits := goutils.CountInitLines(records); inits != 2 || len(records)-inits != 2 {
+		t.Fatalf("Expected two init lines and two events, got %d of %d records", inits, len(records))
+	}
+	if records[0].Severity != goutils.InitSeverity || records[0].Event != goutils.InitEvent {
+		t.Errorf("Expected the file to open with the init event, got %+v", records[0])
+	}
+}
+
+// Test 14: Shutdown With An Expired Context
+// Shutdown returns promptly with the context error and still closes the files.
+func TestShutdownExpired(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	release := make(chan struct{})
+	defer close(release)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithCloseTimeout(0),
+		goutils.WithRotationHook(func(string, string) { <-release }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	start := time.Now()
+	err = logger.Shutdown(ctx)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Shutdown to return promptly, took %s", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, goutils.ErrCloseTimeout) {
+		t.Fatalf("Expected the deadline error, got %v", err)
+	}
+	if _, err := logger.LogsFile.Write([]byte("late")); err == nil {
+		t.Error("Expected the logs file to be closed after the deadline")
+	}
+}
+
+// Test 15: Shutdown
+// Within the deadline Shutdown writes the buffered lines and closes cleanly.
+func TestShutdown(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithBuffering(4096))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Buffered"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := logger.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	logsPath, _ := logger.Paths()
+	if line := findLine(t, logsPath, "Buffered"); line == "" {
+		t.Error("Expected the buffered line on disk after Shutdown")
+	}
+	if err := logger.Close(); !errors.Is(err, goutils.ErrLoggerClosed) {
+		t.Errorf("Expected Close after Shutdown to return ErrLoggerClosed, got %v", err)
+	}
+}
+
+// Test 16: Lazy Events
+// LogIf only builds the event when its severity is enabled.
+func TestLogIf(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithMinSeverity(goutils.Notice))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	called := 0
+	build := func(event string) func() goutils.LogEvent {
+		return func() goutils.LogEvent {
+			called++
+			return goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: event}
+		}
+	}
+	logger.LogIf(goutils.Debug, build("Filtered"))
+	if called != 0 {
+		t.Errorf("Expected the closure not to run below the minimum severity, ran %d times", called)
+	}
+	logger.LogIf(goutils.Notice, build("Written"))
+	if called != 1 {
+		t.Errorf("Expected the closure to run once for an enabled severity, ran %d times", called)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	logsPath, _ := logger.Paths()
+	if findLine(t, logsPath, "Written") == "" || findLine(t, logsPath, "Filtered") != "" {
+		t.Errorf("Expected only the enabled event in the file:\n%s", readFile(t, logsPath))
+	}
+}
+
+// Test 17: Expected Filename
+// The computed path is the file the logger creates, with its zone, interval and extension.
+func TestExpectedFilename(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	// just before midnight in UTC, already the next day in Tokyo
+	now := time.Date(2024, time.March, 5, 23, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	cases := map[string][]goutils.Option{
+		"2024-03-05-":    nil,
+		"2024-03-06-":    {goutils.WithFilenameTimeZone(tokyo)},
+		"2024-03-05T23-": {goutils.WithRotationInterval(goutils.Hourly)},
+		"2024-03-06T08-": {goutils.WithRotationInterval(goutils.Hourly), goutils.WithFilenameTimeZone(tokyo), goutils.WithLiveCompression()},
+	}
+	for prefix, opts := range cases {
+		expected := goutils.ExpectedFilename(tempDir, logsName, now, opts...)
+		logger, err := goutils.NewLogger(tempDir, logsName, errorsName, append(opts, goutils.WithClock(func() time.Time { return now }))...)
+		if err != nil {
+			t.Fatalf("Logger was not initialised: %v", err)
+		}
+		logsPath, _ := logger.Paths()
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if expected != logsPath || !strings.HasPrefix(filepath.Base(expected), prefix) {
+			t.Errorf("Expected %s starting with %s, the logger wrote %s", expected, prefix, logsPath)
+		}
+		if _, err := os.Stat(expected); err != nil {
+			t.Errorf("Expected the file to exist: %v", err)
+		}
+	}
+}
+
+// Test 18: Format Record
+// The previewed bytes are the ones written next, nothing is written or counted by the preview itself.
+func TestFormatRecord(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	now := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithClock(func() time.Time { return now }),
+		goutils.WithMinSeverity(goutils.Notice),
+		goutils.WithFields(map[string]string{"region": "eu"}),
+		goutils.WithServiceName("billing"),
+		goutils.WithSequence(),
+		goutils.WithSeverityPrefix(map[goutils.Severity]string{goutils.Notice: "[NOTE] "}),
+		goutils.WithLineEnding("\r\n"),
+		goutils.WithEmptyEventPolicy(goutils.RejectEmptyEvents))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	event := goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "9", Event: "Invoice sent, 2 pages"}
+
+	preview, err := logger.FormatRecord(goutils.Notice, event)
+	if err != nil {
+		t.Fatalf("FormatRecord failed: %v", err)
+	}
+	again, _ := logger.FormatRecord(goutils.Notice, event)
+	if !bytes.Equal(preview, again) {
+		t.Errorf("Expected previews to leave the sequence alone, got %q then %q", preview, again)
+	}
+	if formatted, err := logger.FormatRecord(goutils.Debug, event); formatted != nil || err != nil {
+		t.Errorf("Expected no bytes for a disabled severity, got %q, %v", formatted, err)
+	}
+	if _, err := logger.FormatRecord(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1"}); !errors.Is(err, goutils.ErrInvalidEvent) {
+		t.Errorf("Expected ErrInvalidEvent for a rejected event, got %v", err)
+	}
+	if drops := logger.DropStats().Invalid; drops != 0 {
+		t.Errorf("Expected previews not to be counted, got %d invalid events", drops)
+	}
+
+	logger.Log(goutils.Notice, event)
+	logger.Close()
+	logsPath, _ := logger.Paths()
+	content, err := os.ReadFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if !bytes.HasSuffix(content, preview) {
+		t.Errorf("Expected the file to end with the preview %q. Got:\n%q", preview, content)
+	}
+	if !bytes.HasPrefix(preview, []byte("[NOTE] NOTICE,2024-03-05T10:00:00Z,")) || !bytes.Contains(preview, []byte(" region=eu\",billing,")) {
+		t.Errorf("Expected the prefix, the fields and the service in the preview, got %q", preview)
+	}
+}
@@ -0,0 +1,63 @@
+package goutils__test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Sidecar Metadata
+// Each log file gets a sidecar describing its layout, rewritten when the file is rotated.
+func TestWithSidecarMetadata(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithSidecarMetadata(), goutils.WithDelimiter('\t'), goutils.WithServiceName("billing"))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+
+	logPath, errPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if _, err := os.Stat(logPath + ".meta.json"); err != nil {
+		t.Fatalf("Expected a sidecar next to the log file: %v", err)
+	}
+	metadata, err := goutils.ReadMetadata(logPath)
+	if err != nil {
+		t.Fatalf("ReadMetadata failed: %v", err)
+	}
+	host, _ := os.Hostname()
+	columns := []string{"severity", "timestamp", "process_type", "process_id", "event", "service"}
+	switch {
+	case metadata.Format != "csv" || metadata.SchemaVersion != 1 || metadata.Delimiter != "\t" || metadata.LineEnding != "\n":
+		t.Errorf("Expected the format of the file, got %+v", metadata)
+	case !reflect.DeepEqual(metadata.Columns, columns):
+		t.Errorf("Expected columns %v, got %v", columns, metadata.Columns)
+	case !reflect.DeepEqual(metadata.Severities, []string{"NOTICE", "DEBUG", "TRACE"}):
+		t.Errorf("Expected the severities of the log file, got %v", metadata.Severities)
+	case metadata.Host != host || metadata.PID != os.Getpid() || metadata.Encrypted || metadata.Compressed:
+		t.Errorf("Expected the host and pid of the process, got %+v", metadata)
+	case metadata.Started.IsZero() || metadata.Opened.IsZero():
+		t.Errorf("Expected start and open times, got %+v", metadata)
+	}
+	errMetadata, err := goutils.ReadMetadata(errPath)
+	if err != nil || !reflect.DeepEqual(errMetadata.Severities, []string{"EMERGENCY", "ALERT", "CRITICAL"}) {
+		t.Errorf("Expected a sidecar for the error file too, got %+v, %v", errMetadata, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	rotated, err := goutils.ReadMetadata(logPath)
+	if err != nil || !rotated.Opened.After(metadata.Opened) || !rotated.Started.Equal(metadata.Started) {
+		t.Errorf("Expected the sidecar rewritten on rotation, got %+v, %v", rotated, err)
+	}
+}
@@ -0,0 +1,60 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Write Function
+// Every formatted line reaches the function with its severity, its errors go to OnError.
+func TestWithWriteFunc(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	rejected := errors.New("remote API unavailable")
+	delivered := map[goutils.Severity][]string{}
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }),
+		goutils.WithWriteFunc(func(severity goutils.Severity, line []byte) error {
+			delivered[severity] = append(delivered[severity], string(line))
+			if severity == goutils.Alert {
+				return rejected
+			}
+			return nil
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Routine"})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Broken"})
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Refused"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if lines := delivered[goutils.Debug]; len(lines) != 1 || !strings.HasPrefix(lines[0], "DEBUG,") || strings.HasSuffix(lines[0], "\n") {
+		t.Errorf("Expected the Debug line without its ending, got %q", lines)
+	}
+	if lines := delivered[goutils.Critical]; len(lines) != 1 || !strings.Contains(lines[0], "Broken") {
+		t.Errorf("Expected the Critical line delivered with its severity, got %q", lines)
+	}
+	if len(delivered[goutils.Trace]) != 1 {
+		t.Errorf("Expected the initialisation line delivered, got %q", delivered[goutils.Trace])
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], rejected) {
+		t.Errorf("Expected the refused line reported through OnError, got %v", reported)
+	}
+
+	_, errorsPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if findLine(t, errorsPath, "Refused") == "" {
+		t.Error("Expected the files still written alongside the function")
+	}
+}
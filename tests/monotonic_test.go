@@ -0,0 +1,78 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper returning a clock that can be stepped backwards like an NTP correction
+func steppingClock(start time.Time) (func() time.Time, func(time.Duration)) {
+	var mu sync.Mutex
+	now := start
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	step := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	return clock, step
+}
+
+// Test 1: Monotonic Guard
+// A clock stepping backwards triggers a Notice, with the clamp later lines keep increasing timestamps.
+func TestWithMonotonicGuard(t *testing.T) {
+	for _, clamp := range []bool{false, true} {
+		tempDir, err := os.MkdirTemp("", "logger_test")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+		t.Cleanup(func() { cleanup(tempDir) })
+
+		clock, step := steppingClock(time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC))
+		guard := goutils.WithMonotonicGuard()
+		if clamp {
+			guard = goutils.WithMonotonicClamp()
+		}
+		logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithClock(clock), guard)
+		if err != nil {
+			t.Fatalf("Logger was not initialised: %v", err)
+		}
+
+		event := goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Ticking"}
+		step(time.Minute)
+		logger.Log(goutils.Debug, event)
+		step(-30 * time.Second)
+		logger.Log(goutils.Debug, event)
+		logger.Close()
+
+		logsPath, _ := logger.Paths()
+		records, err := goutils.ParseLogFile(logsPath)
+		if err != nil {
+			t.Fatalf("Could not parse log file: %v", err)
+		}
+		if len(records) != 4 {
+			t.Fatalf("Expected init, 2 events and the guard notice, got %+v", records)
+		}
+		notice := records[2]
+		if notice.Severity != goutils.Notice || !strings.HasPrefix(notice.Event, "Clock went backwards by 30s") {
+			t.Errorf("Expected the regression notice, got %+v", notice)
+		}
+
+		last := records[3].Timestamp
+		if clamp && last.Before(records[1].Timestamp) {
+			t.Errorf("Expected the clamped timestamp not to go backwards, got %s after %s", last, records[1].Timestamp)
+		}
+		if !clamp && !last.Equal(clock()) {
+			t.Errorf("Expected the unclamped timestamp %s, got %s", clock(), last)
+		}
+	}
+}
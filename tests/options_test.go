@@ -0,0 +1,595 @@
+package goutils__test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Severity Name Overrides
+// Overridden names are written to the files while routing still follows the severity.
+func TestWithSeverityNames(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithSeverityNames(map[goutils.Severity]string{
+			goutils.Emergency: "FATAL",
+			goutils.Notice:    "INFO",
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	logger.Log(goutils.Emergency, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "renamed emergency"})
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "renamed notice"})
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "default debug"})
+
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	contentErr, err := os.ReadFile(expectedErrPath)
+	if err != nil {
+		t.Fatalf("Could not read error file: %v", err)
+	}
+	if !strings.Contains(string(contentErr), "FATAL,") || strings.Contains(string(contentErr), "EMERGENCY") {
+		t.Errorf("Error file does not use the overridden name. Got:\n%s", contentErr)
+	}
+
+	contentLog, err := os.ReadFile(expectedLogPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	strContentLog := string(contentLog)
+	if !strings.Contains(strContentLog, "INFO,") || strings.Contains(strContentLog, "NOTICE") {
+		t.Errorf("Log file does not use the overridden name. Got:\n%s", strContentLog)
+	}
+	if !strings.Contains(strContentLog, "DEBUG,") {
+		t.Errorf("Log file lost the default name for Debug. Got:\n%s", strContentLog)
+	}
+
+	// the package level names must not be affected by the override
+	if got := goutils.Emergency.ToString(); got != "EMERGENCY" {
+		t.Errorf("Expected global name EMERGENCY, got %s", got)
+	}
+}
+
+// Test 2: Instance Scoped Names
+// Two loggers with different overrides log concurrently without affecting each other (run with -race).
+func TestInstanceScopedNames(t *testing.T) {
+	firstDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(firstDir) })
+	secondDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(secondDir) })
+
+	first, err := goutils.NewLogger(firstDir, logsName, errorsName,
+		goutils.WithSeverityNames(map[goutils.Severity]string{goutils.Debug: "DBG"}),
+		goutils.WithProcessTypeNames(map[goutils.ProcessType]string{goutils.GoRoutineProcess: "routine"}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	second, err := goutils.NewLogger(secondDir, logsName, errorsName,
+		goutils.WithSeverityNames(map[goutils.Severity]string{goutils.Debug: "VERBOSE"}),
+		goutils.WithProcessTypeNames(map[goutils.ProcessType]string{goutils.GoRoutineProcess: "worker"}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	routines := 20
+	wg.Add(routines * 2)
+	for i := 0; i < routines; i++ {
+		for _, logger := range []*goutils.Blogger{first, second} {
+			go func(l *goutils.Blogger, val int) {
+				defer wg.Done()
+				l.Log(goutils.Debug, goutils.LogEvent{
+					ProcessType: goutils.GoRoutineProcess,
+					ProcessId:   fmt.Sprintf("%d", val),
+					Event:       "Concurrent names test",
+				})
+			}(logger, i)
+		}
+	}
+	wg.Wait()
+
+	if got := first.SeverityName(goutils.Debug); got != "DBG" {
+		t.Errorf("Expected DBG, got %s", got)
+	}
+	if got := second.ProcessTypeName(goutils.GoRoutineProcess); got != "worker" {
+		t.Errorf("Expected worker, got %s", got)
+	}
+	if got := first.SeverityName(goutils.Trace); got != "TRACE" {
+		t.Errorf("Expected default TRACE, got %s", got)
+	}
+
+	checks := []struct {
+		logger   *goutils.Blogger
+		expected string
+		foreign  string
+	}{
+		{first, "DBG,", "VERBOSE"},
+		{second, "VERBOSE,", "DBG"},
+	}
+	for _, tc := range checks {
+		content, err := os.ReadFile(tc.logger.LogsFile.Name())
+		if err != nil {
+			t.Fatalf("Could not read log file: %v", err)
+		}
+		if got := strings.Count(string(content), tc.expected); got != routines {
+			t.Errorf("Expected %d lines named %s, got %d", routines, tc.expected, got)
+		}
+		if strings.Contains(string(content), tc.foreign) {
+			t.Errorf("Log file contains names of another logger. Got:\n%s", content)
+		}
+	}
+}
+
+// Test 3: Line Endings
+// Lines written with CRLF endings are read back intact.
+func TestWithLineEnding(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithLineEnding("\r\n"))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	events := []string{"First CRLF line", "Second CRLF line"}
+	for _, event := range events {
+		logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: event})
+	}
+
+	content, err := os.ReadFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if !strings.HasSuffix(string(content), "\r\n") {
+		t.Fatalf("Expected content to end with CRLF. Got:\n%q", content)
+	}
+
+	// init line plus the two events
+	lines := strings.Split(strings.TrimSuffix(string(content), "\r\n"), "\r\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), lines)
+	}
+	for i, event := range events {
+		line := lines[i+1]
+		if strings.ContainsAny(line, "\r\n") {
+			t.Errorf("Line contains a stray line terminator: %q", line)
+		}
+		parts := strings.Split(line, ",")
+		if len(parts) != 5 || parts[4] != event {
+			t.Errorf("Expected event %q, got line %q", event, line)
+		}
+	}
+}
+
+// Test 4: Service Name Column
+// The service name is appended to standard and error lines alike.
+func TestWithServiceName(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithServiceName("billing"))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: "Std line"})
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "2", Event: "Error line"})
+
+	for _, file := range logger.Files() {
+		records, err := goutils.ParseLogFile(file.Path)
+		if err != nil {
+			t.Fatalf("Could not parse %s: %v", file.Path, err)
+		}
+		if len(records) == 0 {
+			t.Fatalf("Expected records in %s", file.Path)
+		}
+		for _, record := range records {
+			if record.Extra["column6"] != "billing" {
+				t.Errorf("Expected service column on %+v", record)
+			}
+		}
+	}
+}
+
+// Test 5: Field Delimiters
+// Tab and pipe delimited files round trip through ParseDelimited, even when the event contains the delimiter.
+func TestWithDelimiter(t *testing.T) {
+	for _, delimiter := range []rune{'\t', '|'} {
+		tempDir, err := os.MkdirTemp("", "logger_test")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+		t.Cleanup(func() { cleanup(tempDir) })
+
+		logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithDelimiter(delimiter))
+		if err != nil {
+			t.Fatalf("Logger was not initialised: %v", err)
+		}
+		event := "left" + string(delimiter) + "right"
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: event})
+		logger.Close()
+
+		logPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+		content, err := os.ReadFile(logPath)
+		if err != nil {
+			t.Fatalf("Could not read %s: %v", logPath, err)
+		}
+		if !strings.Contains(string(content), "DEBUG"+string(delimiter)) {
+			t.Errorf("Expected fields separated by %q. Got:\n%s", delimiter, content)
+		}
+
+		records, err := goutils.ParseDelimited(strings.NewReader(string(content)), delimiter)
+		if err != nil {
+			t.Fatalf("Could not parse %q delimited file: %v", delimiter, err)
+		}
+		if len(records) != 2 || records[1].Event != event || records[1].Extra != nil {
+			t.Errorf("Expected event %q to round trip, got %+v", event, records)
+		}
+	}
+
+	for _, delimiter := range []rune{'"', '\n', '\r', 0} {
+		_, err := goutils.NewLogger(os.TempDir(), logsName, errorsName, goutils.WithDelimiter(delimiter))
+		if !errors.Is(err, goutils.ErrInvalidDelimiter) {
+			t.Errorf("Expected ErrInvalidDelimiter for %q, got %v", delimiter, err)
+		}
+	}
+}
+
+// Test 6: Filename Time Zone
+// Just before midnight UTC the files are named after the date in the configured zone, timestamps stay in UTC.
+func TestWithFilenameTimeZone(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	now := time.Date(2026, time.March, 10, 23, 30, 0, 0, time.UTC)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithClock(func() time.Time { return now }),
+		goutils.WithFilenameTimeZone(time.FixedZone("UTC+2", 2*60*60)))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Close()
+
+	logPath := filepath.Join(tempDir, "2026-03-11-"+logsName+".csv")
+	records, err := goutils.ParseLogFile(logPath)
+	if err != nil {
+		t.Fatalf("Expected the file dated in the configured zone: %v", err)
+	}
+	if len(records) != 1 || !records[0].Timestamp.Equal(now) || records[0].Timestamp.Location() != time.UTC {
+		t.Errorf("Expected a UTC timestamp of %s, got %+v", now, records)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "2026-03-10-"+logsName+".csv")); !os.IsNotExist(err) {
+		t.Errorf("Expected no file named after the UTC date, got %v", err)
+	}
+}
+
+// Test 7: Record Separator
+// NUL separated records, including events containing NUL and line breaks, round trip through the parser.
+func TestWithRecordSeparator(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	separator := []byte{0}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithRecordSeparator(separator))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	events := []string{"plain", "with\x00nul", "multi\nline"}
+	for i, event := range events {
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: fmt.Sprint(i), Event: event})
+	}
+	logger.Close()
+
+	logsPath, _ := logger.Paths()
+	content, err := os.ReadFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not read %s: %v", logsPath, err)
+	}
+	// one separator per record plus the one quoted inside an event
+	if !strings.HasSuffix(string(content), "\x00") || strings.Count(string(content), "\x00") != len(events)+2 {
+		t.Errorf("Expected records terminated by NUL, got %q", content)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath, goutils.ParseWithRecordSeparator(separator))
+	if err != nil {
+		t.Fatalf("Could not parse NUL separated file: %v", err)
+	}
+	if len(records) != len(events)+1 {
+		t.Fatalf("Expected %d records, got %+v", len(events)+1, records)
+	}
+	for i, event := range events {
+		if got := records[i+1].Event; got != event {
+			t.Errorf("Record %d: expected event %q, got %q", i+1, event, got)
+		}
+	}
+}
+
+// Test 8: Severity Prefixes
+// Configured severities get their prefix before the fields, the others are written unchanged.
+func TestWithSeverityPrefix(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSeverityPrefix(map[goutils.Severity]string{
+		goutils.Critical: "[ERR] ",
+		goutils.Notice:   "[INF] ",
+	}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	for _, severity := range []goutils.Severity{goutils.Critical, goutils.Alert, goutils.Notice, goutils.Debug} {
+		logger.Log(severity, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Prefixed " + severity.ToString()})
+	}
+	logger.Close()
+
+	logsPath, errorsPath := logger.Paths()
+	expected := map[string]string{
+		"Prefixed CRITICAL": "[ERR] CRITICAL,",
+		"Prefixed ALERT":    "ALERT,",
+		"Prefixed NOTICE":   "[INF] NOTICE,",
+		"Prefixed DEBUG":    "DEBUG,",
+	}
+	for event, start := range expected {
+		path := logsPath
+		if strings.HasPrefix(start, "[ERR]") || start == "ALERT," {
+			path = errorsPath
+		}
+		if line := findLine(t, path, event); !strings.HasPrefix(line, start) {
+			t.Errorf("Expected the line of %q to start with %q, got %q", event, start, line)
+		}
+	}
+}
+
+// Test 9: Single Writer
+// A single-writer logger writes every line in order and rejects background writers.
+func TestWithSingleWriter(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSingleWriter(), goutils.WithBuffering(4096))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: strconv.Itoa(i), Event: "Single"})
+	}
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Routed"})
+	logsPath, errorsPath := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 101 {
+		t.Fatalf("Expected init line and 100 events, got %d", len(records))
+	}
+	for i, record := range records[1:] {
+		if record.ProcessId != strconv.Itoa(i) {
+			t.Fatalf("Expected event %d in order, got %+v", i, record)
+		}
+	}
+	if findLine(t, errorsPath, "Routed") == "" {
+		t.Error("Expected the Critical event in the error file")
+	}
+
+	_, err = goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSingleWriter(), goutils.WithFlushInterval(time.Second))
+	if err == nil {
+		t.Error("Expected WithSingleWriter to be rejected with a background flush")
+	}
+}
+
+// Helper to benchmark logging one Debug event per iteration
+func benchmarkLog(b *testing.B, opts ...goutils.Option) {
+	tempDir, err := os.MkdirTemp("", "logger_bench")
+	if err != nil {
+		b.Fatalf("Failed to create temp dir: %v", err)
+	}
+	b.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, append(opts, goutils.WithBuffering(64*1024))...)
+	if err != nil {
+		b.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+	event := goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Benchmark"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Log(goutils.Debug, event)
+	}
+}
+
+// Benchmark: the default logger, locking the write mutex on every line
+func BenchmarkLogLocked(b *testing.B) {
+	benchmarkLog(b)
+}
+
+// Benchmark: WithSingleWriter, without locking
+func BenchmarkLogSingleWriter(b *testing.B) {
+	benchmarkLog(b, goutils.WithSingleWriter())
+}
+
+// Test 10: Severity Aliases
+// Aliased names are written and parsed back to the real severities, which still decide the routing.
+func TestWithSeverityAlias(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	aliases := map[goutils.Severity]string{
+		goutils.Critical: "CRIT",
+		goutils.Debug:    "INFO",
+		goutils.Trace:    "DEBUG",
+	}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSeverityAlias(aliases))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logged := []goutils.Severity{goutils.Critical, goutils.Notice, goutils.Debug, goutils.Trace}
+	for _, severity := range logged {
+		logger.Log(severity, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "alias", Event: severity.ToString()})
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	contentErr, err := os.ReadFile(expectedErrPath)
+	if err != nil {
+		t.Fatalf("Could not read error file: %v", err)
+	}
+	if !strings.Contains(string(contentErr), "CRIT,") || strings.Contains(string(contentErr), "CRITICAL,") {
+		t.Errorf("Error file does not use the alias. Got:\n%s", contentErr)
+	}
+	contentLog, err := os.ReadFile(expectedLogPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if strings.Contains(string(contentLog), "TRACE,") {
+		t.Errorf("Log file still uses the real name of Trace. Got:\n%s", contentLog)
+	}
+
+	var parsed []goutils.LogRecord
+	for _, path := range []string{expectedErrPath, expectedLogPath} {
+		records, err := goutils.ParseLogFile(path, goutils.ParseWithSeverityAlias(aliases))
+		if err != nil {
+			t.Fatalf("Could not parse %s: %v", path, err)
+		}
+		for _, record := range records {
+			if record.ProcessId == "alias" {
+				parsed = append(parsed, record)
+			}
+		}
+	}
+	if len(parsed) != len(logged) {
+		t.Fatalf("Expected %d parsed records, got %d", len(logged), len(parsed))
+	}
+	for i, record := range parsed {
+		if record.Severity != logged[i] || record.Event != logged[i].ToString() {
+			t.Errorf("Expected %s to round trip, got %s for event %q", logged[i].ToString(), record.Severity.ToString(), record.Event)
+		}
+	}
+
+	// Trace sharing the name of Debug reads back as the more severe Debug
+	collapsed := "DEBUG,2024-03-05T10:00:00Z,Operating System,1,collapsed\n"
+	records, err := goutils.ParseReader(strings.NewReader(collapsed), goutils.ParseWithSeverityAlias(map[goutils.Severity]string{goutils.Trace: "DEBUG"}))
+	if err != nil {
+		t.Fatalf("Could not parse the collapsed alias: %v", err)
+	}
+	if len(records) != 1 || records[0].Severity != goutils.Debug {
+		t.Errorf("Expected a shared name to read as Debug, got %+v", records)
+	}
+}
+
+// Test 11: Local Time
+// Line timestamps and filename dates follow time.Local, a filename zone still wins for the files.
+func TestWithLocalTime(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	// a zone far enough from UTC to move the date
+	local := time.Local
+	time.Local = time.FixedZone("UTC+14", 14*60*60)
+	t.Cleanup(func() { time.Local = local })
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	clock := goutils.WithClock(func() time.Time { return now })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, clock, goutils.WithLocalTime())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Local"})
+	logger.Close()
+
+	logsPath, _ := logger.Paths()
+	if filepath.Base(logsPath) != "2024-03-06-"+logsName+".csv" {
+		t.Errorf("Expected the file dated in local time, got %s", logsPath)
+	}
+	if expected := goutils.ExpectedFilename(tempDir, logsName, now, goutils.WithLocalTime()); expected != logsPath {
+		t.Errorf("Expected ExpectedFilename to follow WithLocalTime, got %s instead of %s", expected, logsPath)
+	}
+	content, err := os.ReadFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if !strings.Contains(string(content), "NOTICE,2024-03-06T02:00:00+14:00,") {
+		t.Errorf("Expected a local timestamp with its offset. Got:\n%s", content)
+	}
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse the log file: %v", err)
+	}
+	if last := records[len(records)-1]; !last.Timestamp.Equal(now) {
+		t.Errorf("Expected the local timestamp to parse back to %v, got %v", now, last.Timestamp)
+	}
+
+	utcDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(utcDir) })
+	utcFiles, err := goutils.NewLogger(utcDir, logsName, errorsName, clock, goutils.WithFilenameTimeZone(time.UTC), goutils.WithLocalTime())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	utcFiles.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Local"})
+	utcFiles.Close()
+
+	utcPath, _ := utcFiles.Paths()
+	if filepath.Base(utcPath) != "2024-03-05-"+logsName+".csv" {
+		t.Errorf("Expected WithFilenameTimeZone to win for the filename, got %s", utcPath)
+	}
+	content, err = os.ReadFile(utcPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if !strings.Contains(string(content), "2024-03-06T02:00:00+14:00") {
+		t.Errorf("Expected the timestamps to stay local. Got:\n%s", content)
+	}
+}
@@ -0,0 +1,154 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Mixed Layouts
+// Legacy five-field lines and extended lines coexist, extras are mapped by header.
+func TestParseMixedLayouts(t *testing.T) {
+	input := strings.Join([]string{
+		"NOTICE,2024-01-02T03:04:05Z,Request,1,legacy line",
+		"severity,timestamp,process_type,process_id,event,service,region",
+		"DEBUG,2024-01-02T03:04:06Z,Goroutine,2,extended line,billing,eu",
+		"TRACE,2024-01-02T03:04:07Z,Operating System,3,legacy after header",
+	}, "\n")
+
+	records, err := goutils.ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader failed: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	legacy := records[0]
+	if legacy.Severity != goutils.Notice || legacy.ProcessType != goutils.RequestProcess ||
+		legacy.ProcessId != "1" || legacy.Event != "legacy line" || legacy.Extra != nil {
+		t.Errorf("Unexpected legacy record: %+v", legacy)
+	}
+	if !legacy.Timestamp.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected timestamp: %s", legacy.Timestamp)
+	}
+
+	extended := records[1]
+	if extended.Severity != goutils.Debug || extended.Event != "extended line" {
+		t.Errorf("Unexpected core fields in extended record: %+v", extended)
+	}
+	if extended.Extra["service"] != "billing" || extended.Extra["region"] != "eu" {
+		t.Errorf("Expected extras mapped by header, got %v", extended.Extra)
+	}
+
+	if records[2].ProcessType != goutils.OsProcess || records[2].Extra != nil {
+		t.Errorf("Unexpected legacy record after header: %+v", records[2])
+	}
+}
+
+// Test 2: Extra Columns Without Header
+// Unnamed extra columns are kept by position while the core fields stay intact.
+func TestParseExtraWithoutHeader(t *testing.T) {
+	input := "ALERT,2024-01-02T03:04:05Z,Request,9,event,extra-one,extra-two\r\n" +
+		"CRITICAL,2024-01-02T03:04:06Z,Request,10,crlf line\r\n"
+
+	records, err := goutils.ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Event != "event" || records[0].Extra["column6"] != "extra-one" || records[0].Extra["column7"] != "extra-two" {
+		t.Errorf("Unexpected record: %+v", records[0])
+	}
+	if records[1].Event != "crlf line" {
+		t.Errorf("Expected CRLF to be stripped, got %q", records[1].Event)
+	}
+}
+
+// Test 3: Round Trip
+// Events containing commas and quotes survive writing and parsing.
+func TestParseLogFileRoundTrip(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithLineEnding("\r\n"))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	event := `user "bob", id 7`
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "7", Event: event})
+
+	records, err := goutils.ParseLogFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected init line and event, got %d records", len(records))
+	}
+	if records[1].Event != event || records[1].Extra != nil {
+		t.Errorf("Expected event %q without extras, got %+v", event, records[1])
+	}
+}
+
+// Test 4: Malformed Lines
+// Lines missing core fields are reported with their line number.
+func TestParseMalformed(t *testing.T) {
+	input := "NOTICE,2024-01-02T03:04:05Z,Request,1,ok\nNOTICE,2024-01-02T03:04:05Z\n"
+
+	records, err := goutils.ParseReader(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("Expected an error for line 2, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected the valid record before the error, got %d", len(records))
+	}
+}
+
+// Test 5: Parse Line
+// A single line is decoded with its quoted fields and extras, malformed ones are described.
+func TestParseLine(t *testing.T) {
+	record, err := goutils.ParseLine("CRITICAL,2024-01-02T03:04:05Z,Request,42,Payment failed\n")
+	if err != nil {
+		t.Fatalf("Could not parse a valid line: %v", err)
+	}
+	if record.Severity != goutils.Critical || record.ProcessType != goutils.RequestProcess || record.ProcessId != "42" || record.Event != "Payment failed" {
+		t.Errorf("Unexpected record %+v", record)
+	}
+	if !record.Timestamp.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected timestamp %v", record.Timestamp)
+	}
+
+	record, err = goutils.ParseLine(`NOTICE,2024-01-02T03:04:05Z,Goroutine,7,"said ""hi"", then
+left",payments`, goutils.ParseWithColumns([]goutils.Column{
+		goutils.SeverityColumn, goutils.TimestampColumn, goutils.ProcessTypeColumn,
+		goutils.ProcessIdColumn, goutils.EventColumn, goutils.ServiceColumn,
+	}))
+	if err != nil {
+		t.Fatalf("Could not parse a quoted line: %v", err)
+	}
+	if record.Event != "said \"hi\", then\nleft" || record.Extra["service"] != "payments" {
+		t.Errorf("Expected the quoted event and the service extra, got %+v", record)
+	}
+
+	for _, malformed := range []struct {
+		line   string
+		reason string
+	}{
+		{"NOTICE,2024-01-02T03:04:05Z,Request", "expected at least 5 fields, got 3"},
+		{"LOUD,2024-01-02T03:04:05Z,Request,1,event", `unknown severity "LOUD"`},
+		{"NOTICE,2024-01-02T03:04:05Z,Request,1,first\nNOTICE,2024-01-02T03:04:05Z,Request,2,second", "more than one record"},
+		{"# just a comment", "no record"},
+	} {
+		if _, err := goutils.ParseLine(malformed.line); err == nil || !strings.Contains(err.Error(), malformed.reason) {
+			t.Errorf("Expected %q to fail with %q, got %v", malformed.line, malformed.reason, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper to run fn guarded by Recover and return what reached the caller
+func guarded(logger *goutils.Blogger, fn func()) (repanicked any) {
+	defer func() { repanicked = recover() }()
+	func() {
+		defer logger.Recover(goutils.OsProcess, "worker-1")()
+		fn()
+	}()
+	return nil
+}
+
+// Test 1: Recover
+// A panic is logged as Critical with its value and stack, then resumed.
+func TestRecover(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	if r := guarded(logger, func() { panic("worker exploded") }); r != "worker exploded" {
+		t.Errorf("Expected the panic to be resumed with its value, got %v", r)
+	}
+
+	_, errorsPath := logger.Paths()
+	line := findLine(t, errorsPath, "worker exploded")
+	if !strings.HasPrefix(line, "CRITICAL") || !strings.Contains(line, "worker-1") {
+		t.Errorf("Expected a Critical line for worker-1, got %q", line)
+	}
+	if content := readFile(t, errorsPath); !strings.Contains(content, "goroutine ") {
+		t.Errorf("Expected the stack trace to be logged, got:\n%s", content)
+	}
+
+	if r := guarded(logger, func() {}); r != nil {
+		t.Errorf("Expected nothing to recover without a panic, got %v", r)
+	}
+}
+
+// Test 2: Swallow Panics
+// With WithSwallowPanics the panic is logged and stops at Recover.
+func TestWithSwallowPanics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSwallowPanics())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	if r := guarded(logger, func() { panic("swallowed") }); r != nil {
+		t.Errorf("Expected the panic to be swallowed, got %v", r)
+	}
+	_, errorsPath := logger.Paths()
+	if line := findLine(t, errorsPath, "swallowed"); !strings.HasPrefix(line, "CRITICAL") {
+		t.Errorf("Expected the swallowed panic to be logged, got %q", line)
+	}
+}
@@ -0,0 +1,60 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Replay JSONL
+// Objects are logged with their own timestamp and routing, malformed lines are reported and skipped.
+func TestReplayJSONL(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	input := strings.Join([]string{
+		`{"severity":"DEBUG","timestamp":"2023-06-01T08:30:00Z","process_type":"Request","process_id":"r-1","event":"Imported request"}`,
+		`{"severity":"debug","timestamp":`,
+		``,
+		`{"severity":"LOUD","process_type":"Request","process_id":"r-2","event":"Unknown severity"}`,
+		`{"severity":"CRITICAL","timestamp":"2023-06-01T08:31:00Z","process_type":"Operating System","process_id":"42","event":"Imported failure"}`,
+	}, "\n")
+	if err := goutils.ReplayJSONL(strings.NewReader(input), logger); err != nil {
+		t.Fatalf("ReplayJSONL failed: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if len(reported) != 2 || !strings.HasPrefix(reported[0].Error(), "line 2:") || !strings.HasPrefix(reported[1].Error(), "line 4:") {
+		t.Errorf("Expected lines 2 and 4 reported, got %v", reported)
+	}
+
+	logPath, errPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	logs, err := goutils.ParseLogFile(logPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(logs) != 2 || logs[1].Event != "Imported request" || logs[1].ProcessType != goutils.RequestProcess ||
+		!logs[1].Timestamp.Equal(time.Date(2023, time.June, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the Debug event with its original timestamp, got %+v", logs)
+	}
+	errs, err := goutils.ParseLogFile(errPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Event != "Imported failure" || errs[0].ProcessId != "42" {
+		t.Errorf("Expected the Critical event routed to the error file, got %+v", errs)
+	}
+}
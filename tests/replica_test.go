@@ -0,0 +1,157 @@
+package goutils__test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Mirror Directory
+// Both directories receive identical files, rotated together.
+func TestWithMirrorDirectory(t *testing.T) {
+	primaryDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(primaryDir) })
+	mirrorDir, err := os.MkdirTemp("", "logger_mirror")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(mirrorDir) })
+
+	logger, err := goutils.NewLogger(primaryDir, logsName, errorsName,
+		goutils.WithMirrorDirectory(mirrorDir), goutils.WithBuffering(4096))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Copied"})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Copied error"})
+	if files := logger.Files(); len(files) != 4 {
+		t.Errorf("Expected the primary and mirror files to be listed, got %+v", files)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After rotation"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	primaryLogs, _ := getExpectedFilenames(primaryDir, logsName, errorsName)
+	mirrorLogs, _ := getExpectedFilenames(mirrorDir, logsName, errorsName)
+	if want, got := readFile(t, primaryLogs), readFile(t, mirrorLogs); !strings.Contains(want, "After rotation") || want != got {
+		t.Errorf("Expected the mirror to match the primary, got:\n%s\nand:\n%s", got, want)
+	}
+	// two active files and two backups in each directory
+	if primary, mirror := entries(t, primaryDir), entries(t, mirrorDir); len(primary) != 4 || len(mirror) != 4 {
+		t.Errorf("Expected both directories rotated, got %v and %v", primary, mirror)
+	}
+}
+
+// writer failing every write, standing in for a lost mount point
+type brokenWriter struct{}
+
+func (brokenWriter) Write(p []byte) (int, error) {
+	return 0, syscall.EIO
+}
+
+func (brokenWriter) Close() error {
+	return nil
+}
+
+// Test 2: Failing Mirror Directory
+// A failing copy is reported to OnError while the healthy one keeps every line.
+func TestMirrorDirectoryFailure(t *testing.T) {
+	primaryDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(primaryDir) })
+	mirrorDir := filepath.Join(primaryDir, "unmounted")
+
+	var mu sync.Mutex
+	var reported []error
+	factory := func(name string) (io.WriteCloser, error) {
+		if strings.HasPrefix(name, mirrorDir) {
+			return brokenWriter{}, nil
+		}
+		return os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	}
+	logger, err := goutils.NewLogger(primaryDir, logsName, errorsName,
+		goutils.WithMirrorDirectory(mirrorDir),
+		goutils.WithWriterFactory(factory),
+		goutils.WithOnError(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, err)
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Still written"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	primaryLogs, _ := getExpectedFilenames(primaryDir, logsName, errorsName)
+	if findLine(t, primaryLogs, "Still written") == "" {
+		t.Error("Expected the healthy copy to keep the line")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 2 {
+		t.Fatalf("Expected the init line and the event reported for the mirror, got %v", reported)
+	}
+	var writeErr *goutils.WriteError
+	if !errors.As(reported[1], &writeErr) || !strings.HasPrefix(writeErr.Path, mirrorDir) || !errors.Is(writeErr, syscall.EIO) {
+		t.Errorf("Expected an EIO write error on the mirror, got %v", reported[1])
+	}
+}
+
+// Test 3: Mirror Directory Unavailable At Startup
+// A mirror directory that cannot be created is reported to OnError, the logger starts with the primary files only.
+func TestMirrorDirectoryUnavailable(t *testing.T) {
+	primaryDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(primaryDir) })
+	blocker := filepath.Join(primaryDir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("Failed to create blocking file: %v", err)
+	}
+	mirrorDir := filepath.Join(blocker, "mirror")
+
+	var reported []error
+	logger, err := goutils.NewLogger(primaryDir, logsName, errorsName,
+		goutils.WithMirrorDirectory(mirrorDir),
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }))
+	if err != nil {
+		t.Fatalf("Expected the logger to start without the mirror, got %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Written without mirror"})
+	files := logger.Files()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var writeErr *goutils.WriteError
+	if len(reported) != 1 || !errors.As(reported[0], &writeErr) || writeErr.Op != "open" || writeErr.Path != mirrorDir || !errors.Is(writeErr, goutils.ErrLogDirCreate) {
+		t.Fatalf("Expected one open error for the mirror directory, got %v", reported)
+	}
+	if len(files) != 2 {
+		t.Errorf("Expected only the primary files, got %+v", files)
+	}
+	primaryLogs, _ := getExpectedFilenames(primaryDir, logsName, errorsName)
+	if findLine(t, primaryLogs, "Written without mirror") == "" {
+		t.Error("Expected the primary copy to keep the line")
+	}
+}
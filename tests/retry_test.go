@@ -0,0 +1,128 @@
+package goutils__test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Writer failing its first writes, the first failure after writing half of the bytes
+type flakyWriter struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	failures int
+	calls    int
+}
+
+func (f *flakyWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.failures == 0 {
+		return f.buf.Write(p)
+	}
+	f.failures--
+	if f.calls == 1 {
+		n, _ := f.buf.Write(p[:len(p)/2])
+		return n, syscall.EIO
+	}
+	return 0, syscall.EINTR
+}
+
+func (f *flakyWriter) String() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.buf.String()
+}
+
+// Test 1: Write Retry
+// A writer failing twice then succeeding receives the whole line exactly once, without reaching OnError.
+func TestWithWriteRetry(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	var reported []error
+	onError := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, err)
+	}
+
+	flaky := &flakyWriter{failures: 2}
+	exhausted := &flakyWriter{failures: 10}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithWriteRetry(3, time.Millisecond),
+		goutils.WithOnError(onError),
+		goutils.WithSeverityRoute(goutils.Alert, flaky),
+		goutils.WithSeverityRoute(goutils.Emergency, exhausted))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	event := "Retried alert event"
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: event})
+	if got := flaky.String(); strings.Count(got, event) != 1 || !strings.HasPrefix(got, "ALERT,") || !strings.HasSuffix(got, "\n") {
+		t.Errorf("Expected the line written once in full, got %q", got)
+	}
+	mu.Lock()
+	if len(reported) != 0 {
+		t.Errorf("Expected no error once the retry succeeded, got %v", reported)
+	}
+	mu.Unlock()
+
+	// attempts are bounded, the last error is reported
+	logger.Log(goutils.Emergency, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Lost emergency"})
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 1 || !errors.Is(reported[0], syscall.EINTR) {
+		t.Errorf("Expected EINTR reported once, got %v", reported)
+	}
+	if exhausted.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", exhausted.calls)
+	}
+}
+
+// Test 2: Bounded Retry Delay
+// A write failing for good gives up after about a second of waiting, however many attempts are allowed.
+func TestWriteRetryDelayCap(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var reported []error
+	broken := &flakyWriter{failures: 1000}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithWriteRetry(1000, 200*time.Millisecond),
+		goutils.WithOnError(func(err error) { reported = append(reported, err) }),
+		goutils.WithSeverityRoute(goutils.Emergency, broken))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	start := time.Now()
+	logger.Log(goutils.Emergency, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Lost emergency"})
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected the retries to give up after about a second, took %s", elapsed)
+	}
+	if broken.calls != 4 {
+		t.Errorf("Expected 4 attempts waiting 200ms, 400ms and the remaining 400ms, got %d", broken.calls)
+	}
+	if len(reported) != 1 {
+		t.Errorf("Expected the failure reported once, got %v", reported)
+	}
+}
@@ -0,0 +1,134 @@
+package goutils__test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Ring Buffer Retention
+// Only the latest N lines are kept, oldest first, across both files.
+func TestWithRingBuffer(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 5
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithRingBuffer(size))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	total := 12
+	for i := 0; i < total; i++ {
+		severity := goutils.Debug
+		if i%2 == 0 {
+			severity = goutils.Critical
+		}
+		logger.Log(severity, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: fmt.Sprintf("line %d", i)})
+	}
+
+	recent := logger.RecentLogs()
+	if len(recent) != size {
+		t.Fatalf("Expected %d recent lines, got %d", size, len(recent))
+	}
+	for i, line := range recent {
+		expected := fmt.Sprintf("line %d", total-size+i)
+		if !strings.HasSuffix(line, ","+expected) {
+			t.Errorf("Expected recent line %d to be %q, got %q", i, expected, line)
+		}
+	}
+}
+
+// Test 2: Ring Buffer Concurrency
+// Reading recent lines while logging is safe and never exceeds the size (run with -race).
+func TestRingBufferConcurrency(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 8
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithRingBuffer(size))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if got := len(logger.RecentLogs()); got != 1 {
+		t.Fatalf("Expected only the init line, got %d lines", got)
+	}
+
+	var wg sync.WaitGroup
+	routines := 20
+	wg.Add(routines * 2)
+	for i := 0; i < routines; i++ {
+		go func(val int) {
+			defer wg.Done()
+			logger.Log(goutils.Trace, goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: fmt.Sprintf("%d", val), Event: "Concurrent"})
+		}(i)
+		go func() {
+			defer wg.Done()
+			if got := len(logger.RecentLogs()); got > size {
+				t.Errorf("Ring buffer exceeded its size: %d", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(logger.RecentLogs()); got != size {
+		t.Errorf("Expected a full ring buffer of %d, got %d", size, got)
+	}
+}
+
+// Test 3: Replay Buffer Across Rotation
+// The latest N events are kept as records, in order, on both sides of a rotation.
+func TestWithReplayBuffer(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 4
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithReplayBuffer(size))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "before 0"})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "before 1"})
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "2", Event: fmt.Sprintf("after %d", i)})
+	}
+
+	replay := logger.Replay()
+	expected := []string{"before 1", "after 0", "after 1", "after 2"}
+	if len(replay) != len(expected) {
+		t.Fatalf("Expected %d replayed events, got %d", len(expected), len(replay))
+	}
+	for i, record := range replay {
+		if record.Event != expected[i] {
+			t.Errorf("Expected replayed event %d to be %q, got %q", i, expected[i], record.Event)
+		}
+		if i > 0 && record.Timestamp.Before(replay[i-1].Timestamp) {
+			t.Errorf("Expected replayed events in timestamp order, got %v after %v", record.Timestamp, replay[i-1].Timestamp)
+		}
+	}
+	if replay[0].Severity != goutils.Critical || replay[0].ProcessType != goutils.OsProcess || replay[0].ProcessId != "1" {
+		t.Errorf("Expected the fields of the first replayed event, got %+v", replay[0])
+	}
+	var unset *goutils.Blogger
+	if unset.Replay() != nil {
+		t.Error("Expected no replay from a nil logger")
+	}
+}
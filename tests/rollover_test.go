@@ -0,0 +1,143 @@
+package goutils__test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Hourly Rotation
+// Crossing the hour writes to a new file named after it, the finished one is left in place.
+func TestHourlyRotation(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	now := time.Date(2024, time.March, 5, 10, 59, 59, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	hooked := make(chan [2]string, 1)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithClock(clock),
+		goutils.WithRotationInterval(goutils.Hourly),
+		goutils.WithRotationHook(func(oldPath, newPath string) {
+			if filepath.Base(oldPath) == "2024-03-05T10-"+logsName+".csv" {
+				hooked <- [2]string{oldPath, newPath}
+			}
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	before := filepath.Join(tempDir, "2024-03-05T10-"+logsName+".csv")
+	if logsPath, _ := logger.Paths(); logsPath != before {
+		t.Fatalf("Expected the file named after the hour, got %s", logsPath)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Before the hour"})
+
+	mu.Lock()
+	now = now.Add(2 * time.Second)
+	mu.Unlock()
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After the hour"})
+
+	after := filepath.Join(tempDir, "2024-03-05T11-"+logsName+".csv")
+	if logsPath, _ := logger.Paths(); logsPath != after || logger.LogsFile.Name() != after {
+		t.Errorf("Expected the logger to move to %s, got %s", after, logsPath)
+	}
+	backups, err := logger.BackupFiles()
+	if err != nil {
+		t.Fatalf("BackupFiles failed: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if findLine(t, before, "Before the hour") == "" || findLine(t, before, "After the hour") != "" {
+		t.Errorf("Expected only the first event in %s:\n%s", before, readFile(t, before))
+	}
+	if findLine(t, after, "After the hour") == "" {
+		t.Errorf("Expected the second event in %s:\n%s", after, readFile(t, after))
+	}
+	select {
+	case paths := <-hooked:
+		if paths != [2]string{before, after} {
+			t.Errorf("Expected the hook called with %s and %s, got %v", before, after, paths)
+		}
+	default:
+		t.Error("Expected the rotation hook to be called for the finished file")
+	}
+	found := false
+	for _, backup := range backups {
+		if backup.Path == before {
+			found = backup.Date.Equal(time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC))
+		}
+	}
+	if !found {
+		t.Errorf("Expected the finished file listed by BackupFiles, got %+v", backups)
+	}
+}
+
+// Test 2: Compression On Daily Rollover
+// Crossing midnight with compression configured leaves only yesterday's gzipped files behind.
+func TestRolloverCompression(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	now := time.Date(2024, time.March, 5, 23, 59, 59, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithClock(clock),
+		goutils.WithRotationInterval(goutils.Daily),
+		goutils.WithCompressionAlgo(goutils.Gzip))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Before midnight"})
+
+	mu.Lock()
+	now = now.Add(2 * time.Second)
+	mu.Unlock()
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After midnight"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	yesterday := filepath.Join(tempDir, "2024-03-05-"+logsName+".csv")
+	for _, name := range []string{logsName, errorsName} {
+		path := filepath.Join(tempDir, "2024-03-05-"+name+".csv")
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s replaced by its compressed copy, got %v", path, err)
+		}
+		if _, err := os.Stat(path + ".gz"); err != nil {
+			t.Errorf("Expected %s.gz: %v", path, err)
+		}
+	}
+	records, err := goutils.ParseLogFile(yesterday + ".gz")
+	if err != nil {
+		t.Fatalf("Could not parse the compressed file: %v", err)
+	}
+	if len(records) != 2 || records[1].Event != "Before midnight" {
+		t.Errorf("Expected yesterday's lines in the compressed file, got %+v", records)
+	}
+	today := filepath.Join(tempDir, "2024-03-06-"+logsName+".csv")
+	if findLine(t, today, "After midnight") == "" {
+		t.Errorf("Expected today's file uncompressed with the new line:\n%s", readFile(t, today))
+	}
+}
@@ -0,0 +1,152 @@
+package goutils__test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Manual Rotation
+// Rotating mid-run leaves a non-empty backup and a fresh empty active file.
+func TestRotate(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	beforeMsg := "Before rotation"
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: beforeMsg})
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	stat, err := os.Stat(expectedLogPath)
+	if err != nil {
+		t.Fatalf("Active log file missing after rotation: %v", err)
+	}
+	if stat.Size() != 0 {
+		t.Errorf("Expected empty active log file, got size %d", stat.Size())
+	}
+	if _, err := os.Stat(expectedErrPath); err != nil {
+		t.Errorf("Active error file missing after rotation: %v", err)
+	}
+
+	backups, err := filepath.Glob(strings.TrimSuffix(expectedLogPath, ".csv") + ".*.csv")
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Expected one log backup, got %v (%v)", backups, err)
+	}
+	content, err := os.ReadFile(backups[0])
+	if err != nil {
+		t.Fatalf("Could not read backup: %v", err)
+	}
+	if !strings.Contains(string(content), beforeMsg) {
+		t.Errorf("Backup missing pre-rotation content. Got:\n%s", content)
+	}
+
+	afterMsg := "After rotation"
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: afterMsg})
+	content, err = os.ReadFile(expectedLogPath)
+	if err != nil {
+		t.Fatalf("Could not read active log file: %v", err)
+	}
+	if !strings.Contains(string(content), afterMsg) || strings.Contains(string(content), beforeMsg) {
+		t.Errorf("Unexpected active file content after rotation. Got:\n%s", content)
+	}
+	if logger.LogsFile.Name() != expectedLogPath {
+		t.Errorf("Expected LogsFile to point at %s, got %s", expectedLogPath, logger.LogsFile.Name())
+	}
+}
+
+// Test 2: Rotation Hook
+// The hook receives the final compressed backup path and the new active path.
+func TestRotationHook(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	type paths struct{ oldPath, newPath string }
+	calls := make(chan paths, 2)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithCompressionAlgo(goutils.Gzip),
+		goutils.WithRotationHook(func(oldPath, newPath string) {
+			calls <- paths{oldPath, newPath}
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	seen := make(map[string]string)
+	for i := 0; i < 2; i++ {
+		select {
+		case call := <-calls:
+			seen[call.newPath] = call.oldPath
+		case <-time.After(time.Second):
+			t.Fatalf("Rotation hook called %d times, expected 2", i)
+		}
+	}
+
+	for _, active := range []string{expectedLogPath, expectedErrPath} {
+		oldPath, ok := seen[active]
+		if !ok {
+			t.Errorf("Hook not called for %s", active)
+			continue
+		}
+		if !strings.HasPrefix(oldPath, strings.TrimSuffix(active, ".csv")+".") || !strings.HasSuffix(oldPath, ".csv.gz") {
+			t.Errorf("Unexpected backup path %s for %s", oldPath, active)
+		}
+		if _, err := os.Stat(oldPath); err != nil {
+			t.Errorf("Backup passed to the hook does not exist: %v", err)
+		}
+	}
+}
+
+// Test 3: Panicking Rotation Hook
+// A panic inside the hook is recovered and routed to OnError.
+func TestRotationHookPanic(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	errs := make(chan error, 2)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithRotationHook(func(oldPath, newPath string) { panic("upload failed") }),
+		goutils.WithOnError(func(err error) { errs <- err }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	logger.Close()
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "upload failed") {
+			t.Errorf("Unexpected error reported: %v", err)
+		}
+	default:
+		t.Fatal("Expected the hook panic to be reported through OnError")
+	}
+}
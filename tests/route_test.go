@@ -0,0 +1,51 @@
+package goutils__test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+	"github.com/biagioPiraino/go-utils/goutilstest"
+)
+
+// Test 1: Severity Route
+// Notice goes to its own writer and nowhere else, other severities keep the default split.
+func TestWithSeverityRoute(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var audit bytes.Buffer
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSeverityRoute(goutils.Notice, &audit))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: "Audited notice"})
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: "Plain debug"})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: "Plain critical"})
+
+	if content := audit.String(); !strings.Contains(content, "NOTICE") || strings.Count(content, "\n") != 1 {
+		t.Errorf("Expected only the notice in the audit writer. Got:\n%s", content)
+	}
+	logs := readFile(t, expectedLogPath)
+	if strings.Contains(logs, "Audited notice") || !strings.Contains(logs, "Plain debug") {
+		t.Errorf("Unexpected standard file content:\n%s", logs)
+	}
+	if errs := readFile(t, expectedErrPath); strings.Contains(errs, "Audited notice") || !strings.Contains(errs, "Plain critical") {
+		t.Errorf("Unexpected error file content:\n%s", errs)
+	}
+
+	for _, severity := range fileInfo(t, logger, expectedLogPath).Severities {
+		if severity == goutils.Notice {
+			t.Error("Standard file still reports Notice among its severities")
+		}
+	}
+	// routed severities are not part of any file, the rest must still be routed correctly
+	goutilstest.AssertRouting(t, logger)
+}
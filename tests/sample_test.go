@@ -0,0 +1,137 @@
+package goutils__test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+const sampledEvent = "Reservoir event"
+
+// Helper to log count trace events tagged with the batch number
+func logTraceBatch(logger *goutils.Blogger, batch int, count int) {
+	for i := 0; i < count; i++ {
+		logger.Log(goutils.Trace, goutils.LogEvent{
+			ProcessType: goutils.GoRoutineProcess,
+			ProcessId:   fmt.Sprintf("%d-%d", batch, i),
+			Event:       sampledEvent,
+		})
+	}
+}
+
+// Test 1: Reservoir Size
+// Out of many events only the reservoir size is written per interval, the rest untouched.
+func TestWithReservoir(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 5
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithReservoir(goutils.Trace, size, time.Hour))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	logTraceBatch(logger, 0, 1000)
+	debugMsg := "Not sampled debug"
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: debugMsg})
+
+	content := readFile(t, expectedLogPath)
+	if strings.Contains(content, sampledEvent) {
+		t.Fatalf("Expected sampled events to wait for the interval. Got:\n%s", content)
+	}
+	if !strings.Contains(content, debugMsg) {
+		t.Errorf("Expected other severities to be written straight away. Got:\n%s", content)
+	}
+
+	// Close ends the interval and flushes the sample
+	logger.Close()
+	content = readFile(t, expectedLogPath)
+	if got := strings.Count(content, sampledEvent); got != size {
+		t.Errorf("Expected %d sampled events, got %d", size, got)
+	}
+}
+
+// Test 2: Reservoir Interval
+// Each interval writes at most size events from the events it received.
+func TestReservoirInterval(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 3
+	interval := 250 * time.Millisecond
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithReservoir(goutils.Trace, size, interval))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	for batch := 0; batch < 2; batch++ {
+		logTraceBatch(logger, batch, 200)
+		time.Sleep(3 * interval)
+		if got := strings.Count(readFile(t, expectedLogPath), sampledEvent); got == 0 || got > size*(batch+1) {
+			t.Errorf("Batch %d: expected between 1 and %d sampled events, got %d", batch, size*(batch+1), got)
+		}
+	}
+	logger.Close()
+
+	if got := strings.Count(readFile(t, expectedLogPath), sampledEvent); got > size*2 {
+		t.Errorf("Expected at most %d sampled events, got %d", size*2, got)
+	}
+}
+
+// Writer sleeping on every write to simulate a congested destination
+type sleepyWriter struct {
+	delay time.Duration
+}
+
+func (s sleepyWriter) Write(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	return len(p), nil
+}
+
+// Test 3: Drain Timeout
+// Close stops writing a full reservoir to a slow destination once the drain timeout expires.
+func TestWithDrainTimeout(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 50
+	timeout := 100 * time.Millisecond
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithReservoir(goutils.Trace, size, time.Hour),
+		goutils.WithSeverityRoute(goutils.Trace, sleepyWriter{delay: 20 * time.Millisecond}),
+		goutils.WithDrainTimeout(timeout))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logTraceBatch(logger, 0, size)
+
+	start := time.Now()
+	err = logger.Close()
+	if elapsed := time.Since(start); elapsed > timeout+500*time.Millisecond {
+		t.Errorf("Expected Close to return shortly after %s, took %s", timeout, elapsed)
+	}
+	if !errors.Is(err, goutils.ErrDrainIncomplete) {
+		t.Errorf("Expected ErrDrainIncomplete, got %v", err)
+	}
+	if abandoned := logger.DropStats().Abandoned; abandoned == 0 || abandoned >= uint64(size) {
+		t.Errorf("Expected part of the sample to be abandoned, got %d", abandoned)
+	}
+}
@@ -0,0 +1,98 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper counting the rotations of a scheduled logger as its clock advances by steps
+func scheduledRotations(t *testing.T, loc *time.Location, start time.Time, schedule []string, steps ...time.Duration) int32 {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	now := start
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	var rotations atomic.Int32
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithClock(clock),
+		goutils.WithFilenameTimeZone(loc),
+		goutils.WithRotationSchedule(schedule),
+		goutils.WithRotationHook(func(oldPath, newPath string) {
+			if strings.HasSuffix(newPath, "-"+logsName+".csv") {
+				rotations.Add(1)
+			}
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	// a few checks of the schedule after every step
+	time.Sleep(600 * time.Millisecond)
+	for _, step := range steps {
+		mu.Lock()
+		now = now.Add(step)
+		mu.Unlock()
+		time.Sleep(600 * time.Millisecond)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return rotations.Load()
+}
+
+// Test 1: Rotation Schedule
+// Crossing a scheduled time rotates the files exactly once.
+func TestWithRotationSchedule(t *testing.T) {
+	start := time.Date(2024, time.March, 5, 11, 59, 50, 0, time.UTC)
+	if count := scheduledRotations(t, time.UTC, start, []string{"00:00", "12:00"}, 20*time.Second, 30*time.Second); count != 1 {
+		t.Errorf("Expected exactly one rotation at 12:00, got %d", count)
+	}
+}
+
+// Test 2: Rotation Schedule Across DST Changes
+// A time repeated when clocks go back fires once, a time skipped when they go forward still fires.
+func TestRotationScheduleDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone database: %v", err)
+	}
+
+	// 01:29 EDT, 01:31 EDT, 01:29 EST, 01:31 EST
+	fallBack := time.Date(2024, time.November, 3, 5, 29, 0, 0, time.UTC)
+	if count := scheduledRotations(t, loc, fallBack, []string{"01:30"}, 2*time.Minute, 58*time.Minute, 2*time.Minute); count != 1 {
+		t.Errorf("Expected one rotation for the repeated 01:30, got %d", count)
+	}
+	// 01:59 EST, 03:01 EDT
+	springForward := time.Date(2024, time.March, 10, 6, 59, 0, 0, time.UTC)
+	if count := scheduledRotations(t, loc, springForward, []string{"02:30"}, 2*time.Minute); count != 1 {
+		t.Errorf("Expected one rotation for the skipped 02:30, got %d", count)
+	}
+}
+
+// Test 3: Malformed Rotation Schedule
+// A time that is not HH:MM is rejected by NewLogger.
+func TestMalformedRotationSchedule(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	if _, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithRotationSchedule([]string{"25:00"})); err == nil {
+		t.Error("Expected an error for 25:00")
+	}
+}
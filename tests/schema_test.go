@@ -0,0 +1,120 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Schema Header
+// The header is written once at the top of a new file and parsed back into its columns.
+func TestWithSchemaHeader(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithSchemaHeader(), goutils.WithServiceName("billing"))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "First"})
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "2", Event: "Second"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	lines := strings.Split(strings.TrimSpace(readFile(t, logPath)), "\n")
+	if count := strings.Count(strings.Join(lines, "\n"), "# go-utils log"); count != 1 {
+		t.Errorf("Expected the header once, found it %d times:\n%s", count, strings.Join(lines, "\n"))
+	}
+	version, columns, ok := goutils.ParseSchemaHeader(lines[0])
+	if !ok {
+		t.Fatalf("First line is not a schema header: %q", lines[0])
+	}
+	expected := []string{"severity", "timestamp", "process_type", "process_id", "event", "service"}
+	if version != 1 || !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Expected v1 with %v, got v%d with %v", expected, version, columns)
+	}
+
+	records, err := goutils.ParseLogFile(logPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 3 || records[2].Event != "Second" || records[2].Extra["service"] != "billing" {
+		t.Errorf("Expected three records with a named service column, got %+v", records)
+	}
+}
+
+// Test 2: Schema Header After Rotation
+// The fresh file opened by Rotate starts with its own header.
+func TestSchemaHeaderAfterRotation(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSchemaHeader())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	logPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	content := readFile(t, logPath)
+	if !strings.HasPrefix(content, "# go-utils log v1 columns: ") {
+		t.Errorf("Expected the rotated file to start with the header, got:\n%s", content)
+	}
+}
+
+// Test 3: Reopening With Other Columns
+// A restart configured with columns the existing header does not declare is refused.
+func TestSchemaMismatchOnReopen(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSchemaHeader())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	conflicting := []goutils.Option{goutils.WithSchemaHeader(), goutils.WithServiceName("billing")}
+	if err := goutils.ValidateLogger(tempDir, logsName, errorsName, conflicting...); !errors.Is(err, goutils.ErrSchemaMismatch) {
+		t.Errorf("Expected ValidateLogger to detect the conflict, got %v", err)
+	}
+	if _, err := goutils.NewLogger(tempDir, logsName, errorsName, conflicting...); !errors.Is(err, goutils.ErrSchemaMismatch) || !errors.Is(err, goutils.ErrLogFileOpen) {
+		t.Errorf("Expected ErrSchemaMismatch for the added service column, got %v", err)
+	}
+	if _, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSequence()); !errors.Is(err, goutils.ErrSchemaMismatch) {
+		t.Errorf("Expected the conflict detected without WithSchemaHeader too, got %v", err)
+	}
+
+	logger, err = goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSchemaHeader())
+	if err != nil {
+		t.Fatalf("Expected the same columns to reopen the file, got %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	logPath, _ := logger.Paths()
+	if count := strings.Count(readFile(t, logPath), "# go-utils log"); count != 1 {
+		t.Errorf("Expected the header kept once after reopening, found it %d times", count)
+	}
+}
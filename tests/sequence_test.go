@@ -0,0 +1,119 @@
+package goutils__test
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Sequence Under Concurrency
+// Lines logged concurrently get unique ordering keys increasing in file order.
+func TestWithSequence(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithSequence(), goutils.WithSchemaHeader())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: strconv.Itoa(g), Event: "Concurrent"})
+			}
+		}(g)
+	}
+	wg.Wait()
+	logsPath, _ := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	if len(records) != 1+8*200 {
+		t.Fatalf("Expected %d records, got %d", 1+8*200, len(records))
+	}
+	for i, record := range records {
+		seq, err := strconv.Atoi(record.Extra["seq"])
+		if err != nil {
+			t.Fatalf("Record %d has no sequence: %+v", i, record)
+		}
+		if i == 0 {
+			continue
+		}
+		previous := records[i-1]
+		previousSeq, _ := strconv.Atoi(previous.Extra["seq"])
+		switch {
+		case record.Timestamp.Before(previous.Timestamp):
+			t.Fatalf("Record %d goes back in time", i)
+		case record.Timestamp.Equal(previous.Timestamp) && seq != previousSeq+1:
+			t.Fatalf("Record %d has seq %d after %d within the same second", i, seq, previousSeq)
+		case record.Timestamp.After(previous.Timestamp) && seq != 0:
+			t.Fatalf("Record %d starts a new second with seq %d", i, seq)
+		}
+	}
+}
+
+// Test 2: Sequence With LogAt
+// Lines logged at an earlier second continue the counter of that second, so no pair repeats.
+func TestSequenceWithLogAt(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	now := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithSequence(), goutils.WithSchemaHeader(),
+		goutils.WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	event := goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Mixed"}
+	for i := 0; i < 3; i++ {
+		logger.Log(goutils.Debug, event)
+		logger.LogAt(past, goutils.Debug, event)
+	}
+	logsPath, _ := logger.Paths()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("ParseLogFile failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	next := make(map[time.Time]int)
+	for i, record := range records {
+		key := record.Timestamp.String() + "/" + record.Extra["seq"]
+		if seen[key] {
+			t.Fatalf("Record %d repeats the pair %s", i, key)
+		}
+		seen[key] = true
+		if seq, _ := strconv.Atoi(record.Extra["seq"]); seq != next[record.Timestamp] {
+			t.Errorf("Record %d at %v has seq %d, expected %d", i, record.Timestamp, seq, next[record.Timestamp])
+		}
+		next[record.Timestamp]++
+	}
+	if next[past] != 3 || next[now] != 4 {
+		t.Errorf("Expected 3 lines in the past and 4 now including the init line, got %v", next)
+	}
+}
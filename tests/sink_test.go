@@ -0,0 +1,525 @@
+package goutils__test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper to find the reported info of the file at path
+func fileInfo(t *testing.T, logger *goutils.Blogger, path string) goutils.FileInfo {
+	for _, info := range logger.Files() {
+		if info.Path == path {
+			return info
+		}
+	}
+	t.Fatalf("File %s is not reported by the logger", path)
+	return goutils.FileInfo{}
+}
+
+// Test 1: File Monitoring
+// Reported sizes grow with every write and match the content on disk.
+func TestFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+
+	if got := len(logger.Files()); got != 2 {
+		t.Fatalf("Expected 2 files, got %d", got)
+	}
+	logsInfo := fileInfo(t, logger, expectedLogPath)
+	errorsInfo := fileInfo(t, logger, expectedErrPath)
+	if errorsInfo.Size != 0 {
+		t.Errorf("Expected empty error file, got size %d", errorsInfo.Size)
+	}
+	// the init line is already in the log file
+	if logsInfo.Size == 0 {
+		t.Error("Expected log file to contain the init line")
+	}
+	if len(errorsInfo.Severities) != 3 || errorsInfo.Severities[0] != goutils.Emergency {
+		t.Errorf("Unexpected severities for error file: %v", errorsInfo.Severities)
+	}
+
+	for i := 0; i < 10; i++ {
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Size test"})
+	}
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Size test"})
+
+	for _, path := range []string{expectedLogPath, expectedErrPath} {
+		info := fileInfo(t, logger, path)
+		stat, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Could not stat %s: %v", path, err)
+		}
+		if info.Size != stat.Size() {
+			t.Errorf("Expected reported size %d for %s, got %d", stat.Size(), path, info.Size)
+		}
+	}
+	if grown := fileInfo(t, logger, expectedLogPath).Size; grown <= logsInfo.Size {
+		t.Errorf("Expected log file size to grow from %d, got %d", logsInfo.Size, grown)
+	}
+}
+
+// In memory destination handed out by memoryFactory
+type memoryWriter struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (m *memoryWriter) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return 0, errors.New("write to closed memory writer")
+	}
+	return m.buf.Write(p)
+}
+
+func (m *memoryWriter) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+func (m *memoryWriter) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.buf.String()
+}
+
+func (m *memoryWriter) isClosed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.closed
+}
+
+// Writer factory keeping every writer it opened, by name and in order
+type memoryFactory struct {
+	mu      sync.Mutex
+	writers map[string][]*memoryWriter
+}
+
+func newMemoryFactory() *memoryFactory {
+	return &memoryFactory{writers: make(map[string][]*memoryWriter)}
+}
+
+func (f *memoryFactory) open(name string) (io.WriteCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &memoryWriter{}
+	f.writers[name] = append(f.writers[name], w)
+	return w, nil
+}
+
+func (f *memoryFactory) opened(name string) []*memoryWriter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*memoryWriter(nil), f.writers[name]...)
+}
+
+// Test 2: Writer Factory
+// Custom writers receive the routed lines, are reopened on rotation and closed on Close.
+func TestWithWriterFactory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+	// the directory must not be needed by custom writers
+	logDir := filepath.Join(tempDir, "remote")
+
+	factory := newMemoryFactory()
+	logger, err := goutils.NewLogger(logDir, logsName, errorsName, goutils.WithWriterFactory(factory.open))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if _, err := os.Stat(logDir); !os.IsNotExist(err) {
+		t.Errorf("Expected no log directory to be created, got %v", err)
+	}
+	if logger.LogsFile != nil || logger.ErrorsFile != nil {
+		t.Error("Expected no *os.File with a writer factory")
+	}
+
+	expectedLogPath, expectedErrPath := getExpectedFilenames(logDir, logsName, errorsName)
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "In memory debug"})
+	logger.Log(goutils.Alert, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "In memory alert"})
+
+	logWriters, errWriters := factory.opened(expectedLogPath), factory.opened(expectedErrPath)
+	if len(logWriters) != 1 || len(errWriters) != 1 {
+		t.Fatalf("Expected one writer per destination, got %d and %d", len(logWriters), len(errWriters))
+	}
+	if content := logWriters[0].String(); !strings.Contains(content, "DEBUG") || strings.Contains(content, "ALERT") {
+		t.Errorf("Unexpected log writer content:\n%s", content)
+	}
+	if content := errWriters[0].String(); !strings.Contains(content, "ALERT") || strings.Contains(content, "DEBUG") {
+		t.Errorf("Unexpected error writer content:\n%s", content)
+	}
+	if size := fileInfo(t, logger, expectedLogPath).Size; size != int64(len(logWriters[0].String())) {
+		t.Errorf("Expected reported size %d, got %d", len(logWriters[0].String()), size)
+	}
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	logWriters = factory.opened(expectedLogPath)
+	if len(logWriters) != 2 || !logWriters[0].isClosed() {
+		t.Fatalf("Expected rotation to close the writer and open a new one, got %d writers", len(logWriters))
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After rotation"})
+	if content := logWriters[1].String(); !strings.Contains(content, "After rotation") {
+		t.Errorf("Expected the new writer to receive lines. Got:\n%s", content)
+	}
+
+	logger.Close()
+	if !logWriters[1].isClosed() || !factory.opened(expectedErrPath)[1].isClosed() {
+		t.Error("Expected Close to close the custom writers")
+	}
+}
+
+// Writer appending one byte at a time, lines tear unless callers serialise writes
+type slowWriter struct {
+	buf []byte
+}
+
+func (s *slowWriter) Write(p []byte) (int, error) {
+	for _, c := range p {
+		s.buf = append(s.buf, c)
+		runtime.Gosched()
+	}
+	return len(p), nil
+}
+
+func (s *slowWriter) Close() error { return nil }
+
+// Test 3: Shared Mutex
+// Two loggers sharing a writer and a mutex never tear each other's lines (run with -race).
+func TestWithMutex(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	shared := &slowWriter{}
+	factory := func(name string) (io.WriteCloser, error) { return shared, nil }
+
+	var mu sync.Mutex
+	first, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithWriterFactory(factory), goutils.WithMutex(&mu))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	second, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithWriterFactory(factory), goutils.WithMutex(&mu))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	routines := 20
+	wg.Add(routines * 2)
+	for i := 0; i < routines; i++ {
+		for _, logger := range []*goutils.Blogger{first, second} {
+			go func(l *goutils.Blogger, val int) {
+				defer wg.Done()
+				l.Log(goutils.Debug, goutils.LogEvent{
+					ProcessType: goutils.GoRoutineProcess,
+					ProcessId:   fmt.Sprintf("%d", val),
+					Event:       "Shared writer line",
+				})
+			}(logger, i)
+		}
+	}
+	wg.Wait()
+
+	mu.Lock()
+	content := string(shared.buf)
+	mu.Unlock()
+
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	// two init lines plus every goroutine
+	if len(lines) != routines*2+2 {
+		t.Fatalf("Expected %d lines, got %d", routines*2+2, len(lines))
+	}
+	for _, line := range lines {
+		parts := strings.Split(line, ",")
+		if len(parts) != 5 || (parts[4] != "Shared writer line" && parts[4] != goutils.InitEvent) {
+			t.Errorf("Torn line: %q", line)
+		}
+	}
+}
+
+// Test 4: Resolved Paths
+// Paths returns the dated files NewLogger created on disk.
+func TestPaths(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	logsPath, errorsPath := logger.Paths()
+	expectedLogPath, expectedErrPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if logsPath != expectedLogPath || errorsPath != expectedErrPath {
+		t.Errorf("Expected paths %s and %s, got %s and %s", expectedLogPath, expectedErrPath, logsPath, errorsPath)
+	}
+	for _, path := range []string{logsPath, errorsPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s on disk: %v", path, err)
+		}
+	}
+}
+
+// Test 5: Single File
+// Every severity lands in the standard file through one handle, concurrent lines are never torn.
+func TestWithSingleFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithSingleFile())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if logger.LogsFile != logger.ErrorsFile {
+		t.Error("Expected the error file to be the standard file")
+	}
+	if files := logger.Files(); len(files) != 1 || len(files[0].Severities) != 6 {
+		t.Errorf("Expected a single file for every severity, got %+v", files)
+	}
+
+	var wg sync.WaitGroup
+	routines := 20
+	wg.Add(routines)
+	for i := 0; i < routines; i++ {
+		go func(val int) {
+			defer wg.Done()
+			severity := goutils.Debug
+			if val%2 == 0 {
+				severity = goutils.Critical
+			}
+			for j := 0; j < 10; j++ {
+				logger.Log(severity, goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: fmt.Sprint(val), Event: strings.Repeat("x", 512)})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logsPath, errorsPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if _, err := os.Stat(errorsPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no error file, got %v", err)
+	}
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse the single file: %v", err)
+	}
+	counts := map[goutils.Severity]int{}
+	for _, record := range records {
+		counts[record.Severity]++
+		if record.Severity != goutils.Trace && len(record.Event) != 512 {
+			t.Errorf("Torn line for %+v", record)
+		}
+	}
+	if counts[goutils.Critical] != routines*5 || counts[goutils.Debug] != routines*5 {
+		t.Errorf("Expected %d critical and debug lines, got %v", routines*5, counts)
+	}
+}
+
+// Test 6: Reset
+// Only lines logged after Reset remain in the files.
+func TestReset(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithBuffering(4096))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Before reset"})
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Before reset"})
+	if err := logger.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "2", Event: "After reset"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logsPath, errorsPath := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != 1 || records[0].Event != "After reset" {
+		t.Errorf("Expected only the post-reset line, got %+v", records)
+	}
+	if content := readFile(t, errorsPath); content != "" {
+		t.Errorf("Expected an empty error file, got %q", content)
+	}
+}
+
+// Test 7: Same Error Filename
+// An empty or identical error filename shares one handle, so concurrent lines are never torn.
+func TestSameErrorFilename(t *testing.T) {
+	for _, errorFilename := range []string{"", logsName} {
+		tempDir, err := os.MkdirTemp("", "logger_test")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+		t.Cleanup(func() { cleanup(tempDir) })
+
+		logger, err := goutils.NewLogger(tempDir, logsName, errorFilename, goutils.WithBuffering(1024))
+		if err != nil {
+			t.Fatalf("Logger was not initialised: %v", err)
+		}
+		if logger.LogsFile != logger.ErrorsFile || len(logger.Files()) != 1 {
+			t.Fatalf("Expected a single shared handle for error filename %q", errorFilename)
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(val int) {
+				defer wg.Done()
+				severity := goutils.Debug
+				if val%2 == 0 {
+					severity = goutils.Critical
+				}
+				for j := 0; j < 20; j++ {
+					logger.Log(severity, goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: fmt.Sprint(val), Event: strings.Repeat("y", 700)})
+				}
+			}(i)
+		}
+		wg.Wait()
+		logsPath, _ := logger.Paths()
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		records, err := goutils.ParseLogFile(logsPath)
+		if err != nil {
+			t.Fatalf("Torn lines for error filename %q: %v", errorFilename, err)
+		}
+		if len(records) != 1+10*20 {
+			t.Errorf("Expected every line intact for error filename %q, got %d records", errorFilename, len(records))
+		}
+	}
+}
+
+// Test 8: Flat Routing
+// A Critical event goes to the standard file with its severity, no error file is created.
+func TestWithFlatRouting(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithFlatRouting())
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Flat critical"})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logsPath, errorsPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	if line := findLine(t, logsPath, "Flat critical"); !strings.HasPrefix(line, "CRITICAL,") {
+		t.Errorf("Expected the Critical line in the standard file, got %q", line)
+	}
+	if _, err := os.Stat(errorsPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no error file, got %v", err)
+	}
+}
+
+// Test 9: Layout
+// Each way of configuring the files reports its routing mode and active paths.
+func TestLayout(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logsPath, errorsPath := getExpectedFilenames(tempDir, logsName, errorsName)
+	cases := []struct {
+		name       string
+		errorsName string
+		opts       []goutils.Option
+		mode       goutils.RoutingMode
+		errorsPath string
+		separate   bool
+	}{
+		{"separate", errorsName, nil, goutils.SeparateFiles, errorsPath, true},
+		{"single", errorsName, []goutils.Option{goutils.WithSingleFile()}, goutils.SingleFile, logsPath, false},
+		{"flat", errorsName, []goutils.Option{goutils.WithFlatRouting()}, goutils.Flat, logsPath, false},
+		{"same filename", logsName, nil, goutils.SingleFile, logsPath, false},
+		{"routed", errorsName, []goutils.Option{goutils.WithSeverityRoute(goutils.Alert, io.Discard)}, goutils.Routed, errorsPath, true},
+		{"routed single", errorsName, []goutils.Option{goutils.WithSingleFile(), goutils.WithSeverityRoute(goutils.Debug, io.Discard)}, goutils.Routed, logsPath, false},
+	}
+	for _, c := range cases {
+		logger, err := goutils.NewLogger(tempDir, logsName, c.errorsName, c.opts...)
+		if err != nil {
+			t.Fatalf("%s: Logger was not initialised: %v", c.name, err)
+		}
+		layout := logger.Layout()
+		if layout.Mode != c.mode || layout.LogsPath != logsPath || layout.ErrorsPath != c.errorsPath {
+			t.Errorf("%s: Expected %s with %s and %s, got %+v", c.name, c.mode.ToString(), logsPath, c.errorsPath, layout)
+		}
+		if logger.HasSeparateErrorFile() != c.separate {
+			t.Errorf("%s: Expected HasSeparateErrorFile %v", c.name, c.separate)
+		}
+		if err := logger.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %v", c.name, err)
+		}
+	}
+
+	mirrorDir := filepath.Join(tempDir, "mirror")
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithMirrorDirectory(mirrorDir), goutils.WithSeverityRoute(goutils.Alert, io.Discard))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	layout := logger.Layout()
+	if len(layout.Replicas) != 2 || filepath.Dir(layout.Replicas[0]) != mirrorDir {
+		t.Errorf("Expected both copies in %s, got %v", mirrorDir, layout.Replicas)
+	}
+	if len(layout.Routed) != 1 || layout.Routed[0] != goutils.Alert {
+		t.Errorf("Expected Alert reported as routed, got %v", layout.Routed)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
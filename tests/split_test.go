@@ -0,0 +1,109 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Large Lines Do Not Interleave
+// Buffered loggers sharing a file write every large line whole, even from many goroutines.
+func TestLargeLinesDoNotInterleave(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var loggers []*goutils.Blogger
+	for i := 0; i < 2; i++ {
+		logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithBuffering(4096))
+		if err != nil {
+			t.Fatalf("Logger was not initialised: %v", err)
+		}
+		loggers = append(loggers, logger)
+	}
+	var wg sync.WaitGroup
+	for i, letter := range "abcdefgh" {
+		wg.Add(1)
+		go func(logger *goutils.Blogger, event string) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: "1", Event: event})
+			}
+		}(loggers[i%2], strings.Repeat(string(letter), 10000+i*1000))
+	}
+	wg.Wait()
+	for _, logger := range loggers {
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+	}
+
+	logPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	records, err := goutils.ParseLogFile(logPath)
+	if err != nil {
+		t.Fatalf("Expected a well formed file, got %v", err)
+	}
+	events := 0
+	for _, record := range records {
+		if record.Event == goutils.InitEvent {
+			continue
+		}
+		events++
+		if strings.Trim(record.Event, record.Event[:1]) != "" {
+			t.Fatalf("Expected every event whole, found one mixing lines: %.40q", record.Event)
+		}
+	}
+	if events != 160 {
+		t.Errorf("Expected 160 events, got %d", events)
+	}
+}
+
+// Test 2: Maximum Line Size
+// An event too long for the limit is split into lines that fit and read back whole.
+func TestWithMaxLineSize(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithMaxLineSize(200))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	event := strings.Repeat(`say "héllo", `, 60)
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: event})
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(readFile(t, logPath), "\n"), "\n") {
+		if len(line) > 200 {
+			t.Errorf("Expected lines of at most 200 bytes, got %d: %q", len(line), line)
+		}
+	}
+	records, err := goutils.ParseLogFile(logPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) < 4 {
+		t.Fatalf("Expected the event split over several lines, got %d records", len(records))
+	}
+	joined := records[1].Event
+	for _, record := range records[2:] {
+		part, ok := strings.CutPrefix(record.Event, goutils.ContinuationMarker)
+		if !ok || !record.Timestamp.Equal(records[1].Timestamp) {
+			t.Fatalf("Expected continuation lines sharing the timestamp, got %+v", record)
+		}
+		joined += part
+	}
+	if joined != event {
+		t.Errorf("Expected the pieces to rejoin into the event, got %q", joined)
+	}
+}
@@ -0,0 +1,170 @@
+package goutils__test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Drop Counters
+// Each drop path increments its own counter and nothing else.
+func TestDropStats(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	size := 4
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithReservoir(goutils.Debug, size, time.Hour))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	if stats := logger.DropStats(); stats != (goutils.DropStats{}) {
+		t.Fatalf("Expected no drops on a new logger, got %+v", stats)
+	}
+
+	// sampling: everything past the reservoir size is dropped
+	for i := 0; i < 10; i++ {
+		logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Sampled"})
+	}
+	if got := logger.DropStats().Sampled; got != uint64(10-size) {
+		t.Errorf("Expected %d sampled drops, got %d", 10-size, got)
+	}
+
+	// cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = logger.LogContext(ctx, goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Cancelled"})
+	_ = logger.LogContext(ctx, goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Cancelled"})
+
+	stats := logger.DropStats()
+	if stats.Cancelled != 2 {
+		t.Errorf("Expected 2 cancelled drops, got %d", stats.Cancelled)
+	}
+	if stats.Sampled != uint64(10-size) {
+		t.Errorf("Expected sampled drops to stay at %d, got %d", 10-size, stats.Sampled)
+	}
+}
+
+// Test 2: Severity Counters
+// Stats accumulates per severity, WindowStats only counts the last window and ResetStats starts over.
+func TestWindowStats(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	wait := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithClock(clock))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	defer logger.Close()
+
+	event := goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Counted"}
+	for i := 0; i < 3; i++ {
+		logger.Log(goutils.Critical, event)
+	}
+	wait(30 * time.Second)
+	logger.Log(goutils.Critical, event)
+	logger.Log(goutils.Notice, event)
+
+	window := logger.WindowStats(time.Minute)
+	if window[goutils.Critical] != 4 || window[goutils.Notice] != 1 {
+		t.Errorf("Expected 4 critical and 1 notice events in the last minute, got %v", window)
+	}
+
+	// the first burst falls out of a 10 second window, then out of the minute
+	if window := logger.WindowStats(10 * time.Second); window[goutils.Critical] != 1 {
+		t.Errorf("Expected 1 critical event in the last 10s, got %v", window)
+	}
+	wait(45 * time.Second)
+	if window := logger.WindowStats(time.Minute); window[goutils.Critical] != 1 {
+		t.Errorf("Expected the window to roll to 1 critical event, got %v", window)
+	}
+	stats := logger.Stats()
+	if stats[goutils.Critical] != 4 || stats[goutils.Notice] != 1 || stats[goutils.Trace] != 1 {
+		t.Errorf("Expected cumulative counts including the init event, got %v", stats)
+	}
+
+	logger.ResetStats()
+	if stats := logger.Stats(); len(stats) != 0 {
+		t.Errorf("Expected no counts after ResetStats, got %v", stats)
+	}
+	if window := logger.WindowStats(time.Hour); len(window) != 0 {
+		t.Errorf("Expected an empty window after ResetStats, got %v", window)
+	}
+}
+
+// Test 3: Write Counters
+// Bytes are summed per severity and the longest line is kept, until ResetStats.
+func TestWriteStats(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	logger.ResetStats()
+
+	var wg sync.WaitGroup
+	for _, length := range []int{10, 100, 1000, 50} {
+		wg.Add(1)
+		go func(length int) {
+			defer wg.Done()
+			logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: strings.Repeat("x", length)})
+		}(length)
+	}
+	wg.Wait()
+	logger.Log(goutils.Critical, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Failure"})
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	logPath, errPath := logger.Paths()
+	logs, errs := readFile(t, logPath), readFile(t, errPath)
+	initLine := strings.SplitAfter(logs, "\n")[0]
+	longest := 0
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		longest = max(longest, len(line))
+	}
+	stats := logger.WriteStats()
+	expected := map[goutils.Severity]uint64{goutils.Debug: uint64(len(logs) - len(initLine)), goutils.Critical: uint64(len(errs))}
+	if !reflect.DeepEqual(stats.BytesWritten, expected) {
+		t.Errorf("Expected the bytes of the lines written since the reset %v, got %v", expected, stats.BytesWritten)
+	}
+	if stats.MaxLineBytes != longest {
+		t.Errorf("Expected the longest line of %d bytes, got %d", longest, stats.MaxLineBytes)
+	}
+
+	logger.ResetStats()
+	if stats := logger.WriteStats(); len(stats.BytesWritten) != 0 || stats.MaxLineBytes != 0 {
+		t.Errorf("Expected write stats zeroed by ResetStats, got %+v", stats)
+	}
+}
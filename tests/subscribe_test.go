@@ -0,0 +1,75 @@
+package goutils__test
+
+import (
+	"os"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Subscribe
+// Only events at or above the subscribed severity arrive, and the channel closes with the logger.
+func TestSubscribe(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	criticals := logger.Subscribe(goutils.Critical)
+	for _, severity := range []goutils.Severity{goutils.Debug, goutils.Critical, goutils.Notice, goutils.Emergency, goutils.Trace} {
+		logger.Log(severity, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: severity.ToString()})
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var received []goutils.Severity
+	for record := range criticals {
+		if record.Event != record.Severity.ToString() || record.Timestamp.IsZero() {
+			t.Errorf("Expected the event as logged, got %+v", record)
+		}
+		received = append(received, record.Severity)
+	}
+	if len(received) != 2 || received[0] != goutils.Critical || received[1] != goutils.Emergency {
+		t.Errorf("Expected the Critical and Emergency events only, got %v", received)
+	}
+	if _, ok := <-logger.Subscribe(goutils.Trace); ok {
+		t.Error("Expected a closed channel when subscribing after Close")
+	}
+}
+
+// Test 2: Slow Subscribers And Unsubscribe
+// A full channel drops and counts events without blocking the logger, Unsubscribe closes it.
+func TestSlowSubscriber(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+	events := logger.Subscribe(goutils.Notice)
+	for i := 0; i < 100; i++ {
+		logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "Burst"})
+	}
+	logger.Unsubscribe(events)
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "After"})
+
+	delivered := 0
+	for range events {
+		delivered++
+	}
+	dropped := logger.DropStats().Subscriber
+	if delivered == 0 || delivered+int(dropped) != 100 {
+		t.Errorf("Expected the 100 events either delivered or counted, got %d delivered and %d dropped", delivered, dropped)
+	}
+}
@@ -0,0 +1,58 @@
+package goutils__test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Transform Pipeline
+// Transforms run in order on written events only, their combined effect is in the line.
+func TestWithTransforms(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	card := regexp.MustCompile(`\d{4}( \d{4}){3}`)
+	calls := 0
+	redact := func(event *goutils.LogEvent) {
+		calls++
+		event.Event = card.ReplaceAllString(event.Event, "[card]")
+	}
+	enrich := func(event *goutils.LogEvent) {
+		// sees the output of redact
+		if strings.Contains(event.Event, "[card]") {
+			event.Event += " pii=redacted"
+		}
+		event.ProcessId = "checkout-" + event.ProcessId
+	}
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithMinSeverity(goutils.Notice),
+		goutils.WithTransforms(redact, enrich))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "7", Event: "Paid with 4242 4242 4242 4242"})
+	logger.Log(goutils.Debug, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "8", Event: "Filtered out"})
+	logger.Close()
+
+	if calls != 1 {
+		t.Errorf("Expected the transforms to run for the written event only, ran %d times", calls)
+	}
+	expectedLogPath, _ := getExpectedFilenames(tempDir, logsName, errorsName)
+	content, err := os.ReadFile(expectedLogPath)
+	if err != nil {
+		t.Fatalf("Could not read log file: %v", err)
+	}
+	if !strings.Contains(string(content), ",Request,checkout-7,Paid with [card] pii=redacted\n") {
+		t.Errorf("Expected both transforms applied in order. Got:\n%s", content)
+	}
+	if strings.Contains(string(content), "4242") {
+		t.Errorf("Expected the card number to be redacted. Got:\n%s", content)
+	}
+}
@@ -0,0 +1,188 @@
+package goutils__test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Helper to build a logger collecting the errors reported through OnError
+func validatingLogger(t *testing.T, rules goutils.EventRules) (*goutils.Blogger, func() []error) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	var mu sync.Mutex
+	var reported []error
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithEventValidation(rules),
+		goutils.WithOnError(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, err)
+		}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	t.Cleanup(func() { logger.Close() })
+
+	return logger, func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]error(nil), reported...)
+	}
+}
+
+// Test 1: Validation Violations
+// Empty events, empty ids, disallowed process types and oversized events are reported and dropped.
+func TestEventValidation(t *testing.T) {
+	logger, reported := validatingLogger(t, goutils.EventRules{
+		MaxEventLength:      10,
+		RequireEvent:        true,
+		RequireProcessId:    true,
+		AllowedProcessTypes: []goutils.ProcessType{goutils.OsProcess, goutils.RequestProcess},
+		Drop:                true,
+	})
+
+	tests := []struct {
+		event     goutils.LogEvent
+		violation string
+	}{
+		{goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1"}, "empty event"},
+		{goutils.LogEvent{ProcessType: goutils.RequestProcess, Event: "No id"}, "empty process id"},
+		{goutils.LogEvent{ProcessType: goutils.GoRoutineProcess, ProcessId: "1", Event: "Goroutine"}, "not allowed"},
+		{goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: "Far too long event"}, "exceeds 10"},
+	}
+	for i, tc := range tests {
+		logger.Log(goutils.Notice, tc.event)
+		errs := reported()
+		if len(errs) != i+1 || !errors.Is(errs[i], goutils.ErrInvalidEvent) || !strings.Contains(errs[i].Error(), tc.violation) {
+			t.Errorf("Expected violation %q, got %v", tc.violation, errs)
+		}
+	}
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "1", Event: "Valid"})
+
+	if got := logger.DropStats().Invalid; got != uint64(len(tests)) {
+		t.Errorf("Expected %d invalid events dropped, got %d", len(tests), got)
+	}
+	logger.Flush()
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != 2 || records[1].Event != "Valid" {
+		t.Errorf("Expected only the init and valid events, got %+v", records)
+	}
+}
+
+// Test 2: Validation Truncation
+// Oversized events are cut to the maximum length without breaking runes and still logged.
+func TestEventValidationTruncate(t *testing.T) {
+	logger, reported := validatingLogger(t, goutils.EventRules{MaxEventLength: 5, Truncate: true, Drop: true})
+
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: "héllo wörld"})
+	if errs := reported(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "truncated") {
+		t.Errorf("Expected the truncation to be reported, got %v", errs)
+	}
+	if got := logger.DropStats().Invalid; got != 0 {
+		t.Errorf("Expected truncated events to be kept, got %d dropped", got)
+	}
+
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != 2 || records[1].Event != "héllo" {
+		t.Errorf("Expected the event truncated to %q, got %+v", "héllo", records)
+	}
+}
+
+// Test 3: Maximum Event Length
+// Oversized multibyte events are cut on a rune boundary and marked with their original size.
+func TestWithMaxEventLength(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithMaxEventLength(8))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	payload := strings.Repeat("日本語", 1000)
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1", Event: payload})
+	logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "2", Event: "Short"})
+	logger.Close()
+
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	expected := "日本語日本語日本…[truncated, 9000 bytes]"
+	if len(records) != 3 || records[1].Event != expected || !utf8.ValidString(records[1].Event) {
+		t.Fatalf("Expected the event truncated to %q, got %+v", expected, records)
+	}
+	if records[2].Event != "Short" {
+		t.Errorf("Expected short events to be kept whole, got %q", records[2].Event)
+	}
+}
+
+// Test 4: Empty Event Policies
+// Empty events are kept, replaced by the placeholder or rejected through OnError.
+func TestWithEmptyEventPolicy(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   goutils.EmptyEventPolicy
+		records  int
+		event    string
+		rejected bool
+	}{
+		{"allow", goutils.AllowEmptyEvents, 2, "", false},
+		{"default", goutils.DefaultEmptyEvent("(empty)"), 2, "(empty)", false},
+		{"reject", goutils.RejectEmptyEvents, 1, "", true},
+	}
+	for _, c := range cases {
+		tempDir, err := os.MkdirTemp("", "logger_test")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+		t.Cleanup(func() { cleanup(tempDir) })
+
+		var reported []error
+		logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+			goutils.WithEmptyEventPolicy(c.policy),
+			goutils.WithOnError(func(err error) { reported = append(reported, err) }))
+		if err != nil {
+			t.Fatalf("%s: Logger was not initialised: %v", c.name, err)
+		}
+		logger.Log(goutils.Notice, goutils.LogEvent{ProcessType: goutils.OsProcess, ProcessId: "1"})
+		dropped := logger.DropStats().Invalid
+		if err := logger.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %v", c.name, err)
+		}
+
+		logsPath, _ := logger.Paths()
+		records, err := goutils.ParseLogFile(logsPath)
+		if err != nil {
+			t.Fatalf("%s: Could not parse log file: %v", c.name, err)
+		}
+		if len(records) != c.records || (c.records == 2 && records[1].Event != c.event) {
+			t.Errorf("%s: Expected %d records ending with %q, got %+v", c.name, c.records, c.event, records)
+		}
+		rejected := len(reported) == 1 && errors.Is(reported[0], goutils.ErrInvalidEvent) && dropped == 1
+		if rejected != c.rejected || (!c.rejected && len(reported) > 0) {
+			t.Errorf("%s: Expected rejected=%v, got errors %v and %d drops", c.name, c.rejected, reported, dropped)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package goutils__test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+type order struct {
+	ID    string
+	Total int
+}
+
+type loggedOrder order
+
+func (o loggedOrder) LogValue() any {
+	return map[string]any{"order": o.ID, "total": o.Total}
+}
+
+type redactedCard string
+
+func (redactedCard) LogValue() any {
+	return "card=****"
+}
+
+// Test 1: Log Values
+// Values implementing LogValuer choose their event, other values are formatted with %+v.
+func TestLogValue(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.LogValue(goutils.Notice, goutils.RequestProcess, "1", loggedOrder{ID: "A-1", Total: 30})
+	logger.LogValue(goutils.Notice, goutils.RequestProcess, "2", redactedCard("4242 4242 4242 4242"))
+	logger.LogValue(goutils.Notice, goutils.RequestProcess, "3", order{ID: "A-2", Total: 12})
+	logger.Close()
+
+	records, err := goutils.ParseLogFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	expected := []string{"order=A-1 total=30", "card=****", "{ID:A-2 Total:12}"}
+	if len(records) != len(expected)+1 {
+		t.Fatalf("Expected %d records, got %d", len(expected)+1, len(records))
+	}
+	for i, event := range expected {
+		if got := records[i+1].Event; got != event {
+			t.Errorf("Record %d: expected event %q, got %q", i+1, event, got)
+		}
+	}
+}
+
+type transfer struct {
+	elapsed time.Duration
+	size    goutils.ByteSize
+}
+
+func (t transfer) LogValue() any {
+	return map[string]any{"dur": t.elapsed, "size": t.size}
+}
+
+// Test 2: Duration And Size Fields
+// Durations and sizes render the same through Fields and LogValue, and parse back.
+func TestDurationAndSizeFields(t *testing.T) {
+	cases := []struct {
+		duration time.Duration
+		size     goutils.ByteSize
+		expected string
+	}{
+		{12500 * time.Microsecond, 1536, "dur=12.500ms size=1.50KiB"},
+		{3250 * time.Millisecond, 512, "dur=3.250s size=512B"},
+		{0, 3 << 30, "dur=0.000ms size=3.00GiB"},
+	}
+
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+
+	for _, c := range cases {
+		fields := goutils.Fields(goutils.Field("dur", c.duration), goutils.Field("size", c.size))
+		if fields != c.expected {
+			t.Errorf("Expected %q from Fields, got %q", c.expected, fields)
+		}
+		logger.LogValue(goutils.Notice, goutils.RequestProcess, "1", transfer{elapsed: c.duration, size: c.size})
+
+		parts := strings.Fields(c.expected)
+		if d, err := time.ParseDuration(strings.TrimPrefix(parts[0], "dur=")); err != nil || d != c.duration {
+			t.Errorf("Expected %s to parse back to %s, got %s (%v)", parts[0], c.duration, d, err)
+		}
+		if s, err := goutils.ParseByteSize(strings.TrimPrefix(parts[1], "size=")); err != nil || s != c.size {
+			t.Errorf("Expected %s to parse back to %d, got %d (%v)", parts[1], c.size, s, err)
+		}
+	}
+	logger.Close()
+
+	records, err := goutils.ParseLogFile(logger.LogsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	for i, c := range cases {
+		if got := records[i+1].Event; got != c.expected {
+			t.Errorf("Expected %q from LogValue, got %q", c.expected, got)
+		}
+	}
+}
+
+// Test 3: Per-Call Fields
+// Fields given to one call appear on that line only and override the logger fields.
+func TestLogWithFields(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName,
+		goutils.WithFields(map[string]string{"region": "eu", "tier": "free"}))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	event := goutils.LogEvent{ProcessType: goutils.RequestProcess, ProcessId: "r-1", Event: "Checkout"}
+	logger.LogWithFields(goutils.Notice, event, map[string]string{"order": "42", "tier": "pro plan"})
+	event.Event = "Next"
+	logger.Log(goutils.Notice, event)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logsPath, _ := logger.Paths()
+	records, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected three records, got %+v", records)
+	}
+	if expected := `Checkout order=42 region=eu tier="pro plan"`; records[1].Event != expected {
+		t.Errorf("Expected %q with the call overriding the logger field, got %q", expected, records[1].Event)
+	}
+	if expected := "Next region=eu tier=free"; records[2].Event != expected {
+		t.Errorf("Expected %q without the previous call's fields, got %q", expected, records[2].Event)
+	}
+}
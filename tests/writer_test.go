@@ -0,0 +1,118 @@
+package goutils__test
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	goutils "github.com/biagioPiraino/go-utils"
+)
+
+// Test 1: Writer Adapter
+// Lines written through log.New and partial writes are logged one event per line.
+func TestWriter(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	w := logger.Writer(goutils.Critical, goutils.GoRoutineProcess)
+
+	stdLogger := log.New(w, "http: ", 0)
+	stdLogger.Print("TLS handshake error")
+	stdLogger.Print("first\nsecond")
+
+	// a line split across writes is logged once complete
+	w.Write([]byte("partial "))
+	w.Write([]byte("line\r\nleft"))
+	logger.Close()
+
+	records, err := goutils.ParseLogFile(logger.ErrorsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse errors file: %v", err)
+	}
+	expected := []string{"http: TLS handshake error", "http: first", "second", "partial line"}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %+v", len(expected), records)
+	}
+	for i, event := range expected {
+		record := records[i]
+		if record.Event != event || record.Severity != goutils.Critical || record.ProcessType != goutils.GoRoutineProcess {
+			t.Errorf("Record %d: expected critical goroutine event %q, got %+v", i, event, record)
+		}
+	}
+}
+
+// Test 2: Standard Library Logger
+// Print through the returned *log.Logger lands in the file of its severity.
+func TestStdLogger(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName)
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	logger.StdLogger(goutils.Alert, goutils.OsProcess).Print("legacy failure")
+	logger.StdLogger(goutils.Debug, goutils.GoRoutineProcess).Printf("legacy %s", "detail")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	logsPath, errorsPath := logger.Paths()
+	errs, err := goutils.ParseLogFile(errorsPath)
+	if err != nil {
+		t.Fatalf("Could not parse errors file: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Event != "legacy failure" || errs[0].Severity != goutils.Alert {
+		t.Errorf("Expected the Alert line in the error file, got %+v", errs)
+	}
+	logs, err := goutils.ParseLogFile(logsPath)
+	if err != nil {
+		t.Fatalf("Could not parse log file: %v", err)
+	}
+	if len(logs) != 2 || logs[1].Event != "legacy detail" || logs[1].Severity != goutils.Debug || logs[1].ProcessType != goutils.GoRoutineProcess {
+		t.Errorf("Expected the Debug line in the log file, got %+v", logs)
+	}
+}
+
+// Test 3: Writer Partial Line Limit
+// Output without newlines is logged once it reaches the line size limit instead of piling up.
+func TestWriterPartialLimit(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "logger_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { cleanup(tempDir) })
+
+	logger, err := goutils.NewLogger(tempDir, logsName, errorsName, goutils.WithMaxLineSize(200))
+	if err != nil {
+		t.Fatalf("Logger was not initialised: %v", err)
+	}
+	w := logger.Writer(goutils.Critical, goutils.GoRoutineProcess)
+	for range 10 {
+		w.Write([]byte(strings.Repeat("x", 50)))
+	}
+	logger.Close()
+
+	records, err := goutils.ParseLogFile(logger.ErrorsFile.Name())
+	if err != nil {
+		t.Fatalf("Could not parse errors file: %v", err)
+	}
+	total := 0
+	for _, record := range records {
+		total += len(strings.TrimPrefix(record.Event, goutils.ContinuationMarker))
+	}
+	if len(records) == 0 || total != 400 {
+		t.Errorf("Expected the first 400 bytes logged and the last 100 kept, got %d bytes in %+v", total, records)
+	}
+}
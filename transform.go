@@ -0,0 +1,20 @@
+package goutils
+
+// WithTransforms runs the given functions in order on every event passed to
+// Log and its variants, once it is known to be written and has passed
+// validation, e.g. to redact, enrich and then normalise it. They cannot
+// change the severity so they never bypass WithMinSeverity, and see the
+// event before the fields of WithFields or LogWithFields are appended.
+// Transforms are called concurrently when logging from several goroutines.
+func WithTransforms(transforms ...func(*LogEvent)) Option {
+	return func(c *config) {
+		c.transforms = append(c.transforms, transforms...)
+	}
+}
+
+func (c config) transform(process LogEvent) LogEvent {
+	for _, transform := range c.transforms {
+		transform(&process)
+	}
+	return process
+}
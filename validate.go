@@ -0,0 +1,146 @@
+package goutils
+
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+// EventRules describe what a well formed event looks like, see WithEventValidation.
+type EventRules struct {
+	MaxEventLength      int           // in runes, 0 allows any length
+	RequireEvent        bool          // Event must not be empty
+	RequireProcessId    bool          // ProcessId must not be empty
+	AllowedProcessTypes []ProcessType // nil allows every process type
+
+	Drop     bool // drop invalid events instead of logging them anyway
+	Truncate bool // cut oversized events to MaxEventLength, they are no longer invalid
+}
+
+// WithEventValidation checks every event against rules at log time. Each
+// violation is reported to OnError as an ErrInvalidEvent; invalid events are
+// still logged unless rules.Drop is set, in which case they are counted in
+// DropStats.
+func WithEventValidation(rules EventRules) Option {
+	return func(c *config) {
+		c.eventRules = &rules
+	}
+}
+
+// EmptyEventPolicy decides what happens to events with an empty Event, see
+// WithEmptyEventPolicy.
+type EmptyEventPolicy struct {
+	reject      bool
+	placeholder string
+}
+
+var (
+	AllowEmptyEvents  = EmptyEventPolicy{}             // written with an empty last field
+	RejectEmptyEvents = EmptyEventPolicy{reject: true} // dropped
+)
+
+// DefaultEmptyEvent writes placeholder, e.g. "(empty)", in place of an empty
+// Event.
+func DefaultEmptyEvent(placeholder string) EmptyEventPolicy {
+	return EmptyEventPolicy{placeholder: placeholder}
+}
+
+// WithEmptyEventPolicy handles events logged with an empty Event, which
+// otherwise end the line with an empty field some parsers reject. With
+// RejectEmptyEvents they are reported to OnError as an ErrInvalidEvent and
+// counted in DropStats; AllowEmptyEvents is the default.
+func WithEmptyEventPolicy(policy EmptyEventPolicy) Option {
+	return func(c *config) {
+		c.emptyEvent = policy
+	}
+}
+
+// WithMaxEventLength cuts events longer than n runes and appends a marker
+// with the original length in bytes, e.g. "…[truncated, 5242880 bytes]", so
+// a giant payload cannot blow a line up to megabytes. Runes are never split.
+func WithMaxEventLength(n int) Option {
+	return func(c *config) {
+		c.maxEventLength = n
+	}
+}
+
+func (c config) capEvent(process LogEvent) LogEvent {
+	if c.maxEventLength <= 0 || utf8.RuneCountInString(process.Event) <= c.maxEventLength {
+		return process
+	}
+	process.Event = fmt.Sprintf("%s…[truncated, %d bytes]", truncateRunes(process.Event, c.maxEventLength), len(process.Event))
+	return process
+}
+
+// validate returns the event to log, repaired if possible, the violations
+// found and whether some of them could not be repaired
+func (r *EventRules) validate(process LogEvent) (LogEvent, []error, bool) {
+	var violations []error
+	if r.RequireEvent && process.Event == "" {
+		violations = append(violations, fmt.Errorf("%w: empty event", ErrInvalidEvent))
+	}
+	if r.RequireProcessId && process.ProcessId == "" {
+		violations = append(violations, fmt.Errorf("%w: empty process id", ErrInvalidEvent))
+	}
+	if r.AllowedProcessTypes != nil && !slices.Contains(r.AllowedProcessTypes, process.ProcessType) {
+		violations = append(violations, fmt.Errorf("%w: process type %d not allowed", ErrInvalidEvent, process.ProcessType))
+	}
+	invalid := len(violations) > 0
+
+	if r.MaxEventLength > 0 {
+		if length := utf8.RuneCountInString(process.Event); length > r.MaxEventLength {
+			err := fmt.Errorf("%w: event of %d runes exceeds %d", ErrInvalidEvent, length, r.MaxEventLength)
+			if r.Truncate {
+				process.Event = truncateRunes(process.Event, r.MaxEventLength)
+				err = fmt.Errorf("%w, truncated", err)
+			} else {
+				invalid = true
+			}
+			violations = append(violations, err)
+		}
+	}
+	return process, violations, invalid
+}
+
+// checkEvent applies the validation rules, reporting violations through
+// OnError, and tells whether the event should still be logged
+func (b *Blogger) checkEvent(process LogEvent) (LogEvent, bool) {
+	checked, violations, ok := b.cfg.checkEvent(process)
+	for _, err := range violations {
+		b.cfg.onError(err)
+	}
+	if !ok {
+		b.drops.invalid.Add(1)
+	}
+	return checked, ok
+}
+
+// checkEvent returns the event to log, the violations to report and
+// whether the event is kept, without reporting or counting anything
+func (c config) checkEvent(process LogEvent) (LogEvent, []error, bool) {
+	if process.Event == "" {
+		if c.emptyEvent.reject {
+			return process, []error{fmt.Errorf("%w: empty event", ErrInvalidEvent)}, false
+		}
+		process.Event = c.emptyEvent.placeholder
+	}
+
+	rules := c.eventRules
+	if rules == nil {
+		return process, nil, true
+	}
+
+	checked, violations, invalid := rules.validate(process)
+	return checked, violations, !invalid || !rules.Drop
+}
+
+// the first n runes of s, never splitting a multibyte rune
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
@@ -0,0 +1,189 @@
+package goutils
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogValuer is implemented by types choosing how they appear in an event.
+// LogValue returns either a string, used as the event as is, or a
+// map[string]any, written as space separated key=value pairs sorted by key.
+type LogValuer interface {
+	LogValue() any
+}
+
+// LogValue logs v as the event, through LogValuer when v implements it and
+// formatted with %+v otherwise.
+func (b *Blogger) LogValue(severity Severity, processType ProcessType, id string, v any) {
+	if !b.IsEnabled(severity) {
+		return
+	}
+	b.Log(severity, LogEvent{ProcessType: processType, ProcessId: id, Event: eventOf(v)})
+}
+
+func eventOf(v any) string {
+	valuer, ok := v.(LogValuer)
+	if !ok {
+		return fmt.Sprintf("%+v", v)
+	}
+
+	switch value := valuer.LogValue().(type) {
+	case string:
+		return value
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, len(keys))
+		for i, key := range keys {
+			pairs[i] = Field(key, value[key]).String()
+		}
+		return strings.Join(pairs, " ")
+	default:
+		return fmt.Sprintf("%+v", value)
+	}
+}
+
+// WithFields appends fields to the event of every line as key=value pairs
+// sorted by key, e.g. a Clone(WithFields(...)) tagging the lines of one
+// subsystem. Fields given to LogWithFields are added to them for one line.
+func WithFields(fields map[string]string) Option {
+	return func(c *config) {
+		if c.fields == nil {
+			c.fields = make(map[string]string, len(fields))
+		}
+		for key, value := range fields {
+			c.fields[key] = value
+		}
+	}
+}
+
+// appendFields renders the logger fields overridden by the given ones after
+// the event
+func (c config) appendFields(event string, fields map[string]string) string {
+	if len(c.fields) == 0 && len(fields) == 0 {
+		return event
+	}
+	merged := maps.Clone(c.fields)
+	if merged == nil {
+		merged = make(map[string]string, len(fields))
+	}
+	maps.Copy(merged, fields)
+
+	pairs := make([]string, 0, len(merged))
+	for _, key := range slices.Sorted(maps.Keys(merged)) {
+		pairs = append(pairs, Field(key, merged[key]).String())
+	}
+	if event == "" {
+		return strings.Join(pairs, " ")
+	}
+	return event + " " + strings.Join(pairs, " ")
+}
+
+// KeyValue is a named value rendered as key=value in an event, see Fields.
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// Field names value for Fields. Durations are written in milliseconds below
+// a second and in seconds above, with three decimals (e.g. "12.500ms",
+// "3.250s"), ByteSize values in binary units; both parse back with
+// time.ParseDuration and ParseByteSize. Strings containing spaces, quotes
+// or "=" are quoted.
+func Field(key string, value any) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+func (kv KeyValue) String() string {
+	return kv.Key + "=" + formatValue(kv.Value)
+}
+
+// Fields renders fields as space separated key=value pairs in the given
+// order, the same way LogValue renders a map, for use as an event:
+//
+//	logger.Log(goutils.Notice, goutils.LogEvent{
+//		ProcessType: goutils.RequestProcess,
+//		ProcessId:   id,
+//		Event:       goutils.Fields(goutils.Field("dur", elapsed), goutils.Field("size", goutils.ByteSize(n))),
+//	})
+func Fields(fields ...KeyValue) string {
+	pairs := make([]string, len(fields))
+	for i, field := range fields {
+		pairs[i] = field.String()
+	}
+	return strings.Join(pairs, " ")
+}
+
+func formatValue(v any) string {
+	switch value := v.(type) {
+	case time.Duration:
+		if value < time.Second && value > -time.Second {
+			return strconv.FormatFloat(float64(value)/float64(time.Millisecond), 'f', 3, 64) + "ms"
+		}
+		return strconv.FormatFloat(value.Seconds(), 'f', 3, 64) + "s"
+	case string:
+		if strings.ContainsAny(value, " \t\"=") {
+			return strconv.Quote(value)
+		}
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// ByteSize is a number of bytes rendered in binary units with two decimals,
+// e.g. "512B", "1.50KiB" or "3.00GiB".
+type ByteSize int64
+
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+func (s ByteSize) String() string {
+	if s < 1024 && s > -1024 {
+		return strconv.FormatInt(int64(s), 10) + "B"
+	}
+	value := float64(s) / 1024
+	unit := 0
+	for (value >= 1024 || value <= -1024) && unit < len(byteUnits)-1 {
+		value /= 1024
+		unit++
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64) + byteUnits[unit]
+}
+
+// ParseByteSize parses a size written by ByteSize.String, rounding to the
+// nearest byte.
+func ParseByteSize(s string) (ByteSize, error) {
+	for i := len(byteUnits) - 1; i >= 0; i-- {
+		if number, ok := strings.CutSuffix(s, byteUnits[i]); ok {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q: %w", s, err)
+			}
+			for j := 0; j <= i; j++ {
+				value *= 1024
+			}
+			if value < 0 {
+				return ByteSize(value - 0.5), nil
+			}
+			return ByteSize(value + 0.5), nil
+		}
+	}
+	number, ok := strings.CutSuffix(s, "B")
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: missing unit", s)
+	}
+	value, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	return ByteSize(value), nil
+}
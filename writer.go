@@ -0,0 +1,73 @@
+package goutils
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+)
+
+// Writer returns an io.Writer logging every line written to it as an event
+// at the given severity and process type, e.g. for http.Server.ErrorLog:
+//
+//	server.ErrorLog = log.New(logger.Writer(Critical, GoRoutineProcess), "", 0)
+//
+// Lines may be split across writes, a trailing partial line is kept until
+// its newline arrives. A partial line reaching the WithMaxLineSize limit
+// (64 KiB without it) is logged as it is instead, so output that never
+// writes a newline does not grow the buffer forever. The writer is safe for
+// concurrent use.
+func (b *Blogger) Writer(severity Severity, processType ProcessType) io.Writer {
+	return &lineWriter{logger: b, severity: severity, processType: processType}
+}
+
+// StdLogger returns a *log.Logger writing to Writer(severity, processType),
+// so legacy calls such as Print and Printf become events routed like any
+// other. It has no prefix nor flags since every line carries its own
+// timestamp; a message spanning several lines becomes several events.
+func (b *Blogger) StdLogger(severity Severity, processType ProcessType) *log.Logger {
+	return log.New(b.Writer(severity, processType), "", 0)
+}
+
+// longest partial line kept without WithMaxLineSize
+const maxPartialLine = 64 << 10
+
+type lineWriter struct {
+	logger      *Blogger
+	severity    Severity
+	processType ProcessType
+
+	mu      sync.Mutex
+	partial []byte // bytes written after the last newline
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	start := 0
+	for {
+		i := bytes.IndexByte(w.partial[start:], '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimSuffix(w.partial[start:start+i], []byte("\r"))
+		w.logger.Log(w.severity, LogEvent{ProcessType: w.processType, Event: string(line)})
+		start += i + 1
+	}
+	// move the partial line to the front so the buffer is reused
+	w.partial = append(w.partial[:0], w.partial[start:]...)
+	if len(w.partial) >= w.limit() {
+		w.logger.Log(w.severity, LogEvent{ProcessType: w.processType, Event: string(w.partial)})
+		w.partial = w.partial[:0]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) limit() int {
+	if w.logger == nil || w.logger.cfg.maxLineSize <= 0 {
+		return maxPartialLine
+	}
+	return w.logger.cfg.maxLineSize
+}